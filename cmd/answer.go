@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+)
+
+// answerOptions holds the options for the answer command
+type answerOptions struct {
+	newClient client.Factory
+	out       io.Writer
+	unmark    bool
+}
+
+// NewAnswerCmd creates the answer command
+func NewAnswerCmd(f client.Factory) *cobra.Command {
+	opts := &answerOptions{newClient: f}
+
+	cmd := &cobra.Command{
+		Use:   "answer {<comment-url> | <comment-id>}...",
+		Short: "Mark or unmark a comment as the answer",
+		Long: `Mark a discussion comment as the answer to its discussion.
+
+Comments can be given as URLs such as
+https://github.com/owner/repo/discussions/123#discussioncomment-456 or as
+comment node IDs. Only top-level comments in answerable categories can be
+marked as the answer.
+
+With '--unmark', remove the answer mark from the comment instead.`,
+		Example: `  # Mark a comment as the answer
+  gh discussion answer https://github.com/owner/repo/discussions/123#discussioncomment-456
+
+  # Mark several comments in different discussions as answers
+  gh discussion answer DC_kwDOAbc123 DC_kwDOAbc456
+
+  # Unmark a comment as the answer
+  gh discussion answer --unmark DC_kwDOAbc123`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.out = cmd.OutOrStdout()
+			return runAnswer(cmd.Context(), opts, args)
+		},
+	}
+
+	// Answer options
+	cmd.Flags().BoolVar(&opts.unmark, "unmark", false, "Unmark the comment as the answer")
+
+	return cmd
+}
+
+// runAnswer executes the answer command
+func runAnswer(ctx context.Context, opts *answerOptions, commentArgs []string) error {
+	for _, arg := range commentArgs {
+		// Comments may live on different hosts, so create a client for each
+		client, err := opts.newClient(commentHost(arg))
+		if err != nil {
+			return fmt.Errorf("failed to create GitHub client: %w", err)
+		}
+
+		if err := markAnswer(ctx, opts.out, client, arg, opts.unmark); err != nil {
+			return fmt.Errorf("%s: %w", arg, err)
+		}
+	}
+
+	return nil
+}
+
+// markAnswer marks or unmarks a single comment as the answer after checking
+// that the viewer is allowed to do so
+func markAnswer(ctx context.Context, out io.Writer, c client.DiscussionAPI, ref string, unmark bool) error {
+	commentID, err := resolveCommentID(ctx, c, ref)
+	if err != nil {
+		return err
+	}
+
+	comment, err := c.GetDiscussionCommentWithContext(ctx, commentID)
+	if err != nil {
+		return err
+	}
+
+	discussion := comment.Discussion
+	if discussion == nil {
+		return fmt.Errorf("comment %s does not belong to a discussion", commentID)
+	}
+
+	if discussion.Category == nil || !discussion.Category.IsAnswerable {
+		category := ""
+		if discussion.Category != nil {
+			category = discussion.Category.Name
+		}
+		return invalidInput("discussion #%d is in category %q, which does not accept answers", discussion.Number, category)
+	}
+
+	if unmark {
+		if !comment.IsAnswer {
+			fmt.Fprintf(out, "Comment is not marked as the answer to discussion #%d (%s)\n", discussion.Number, discussion.Title)
+			return nil
+		}
+		if !comment.ViewerCanUnmarkAsAnswer {
+			return forbidden("you do not have permission to unmark the answer to discussion #%d", discussion.Number)
+		}
+		if err := c.UnmarkCommentAsAnswerWithContext(ctx, comment.ID); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Unmarked answer to discussion #%d (%s)\n", discussion.Number, discussion.Title)
+		return nil
+	}
+
+	if comment.IsAnswer {
+		fmt.Fprintf(out, "Comment is already the answer to discussion #%d (%s)\n", discussion.Number, discussion.Title)
+		return nil
+	}
+	if comment.ReplyTo != nil {
+		return invalidInput("only top-level comments can be marked as the answer")
+	}
+	if !comment.ViewerCanMarkAsAnswer {
+		return forbidden("you do not have permission to mark the answer to discussion #%d", discussion.Number)
+	}
+	if err := c.MarkCommentAsAnswerWithContext(ctx, comment.ID); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Marked answer to discussion #%d (%s)\n", discussion.Number, discussion.Title)
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+	"github.com/harakeishi/gh-discussion/pkg/models"
+)
+
+func TestRunAnswer(t *testing.T) {
+	fake, repo := newTestFake(t)
+	repo.Discussion(1).Comments.Nodes[0].ViewerCanMarkAsAnswer = true
+
+	var out bytes.Buffer
+	opts := &answerOptions{newClient: fake.Factory(), out: &out}
+	if err := runAnswer(context.Background(), opts, []string{"DC_parent"}); err != nil {
+		t.Fatalf("runAnswer() error = %v", err)
+	}
+	if got, want := out.String(), "Marked answer to discussion #1 (How do I deploy?)\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+	if !repo.Discussion(1).Comments.Nodes[0].IsAnswer {
+		t.Error("comment was not marked as the answer")
+	}
+
+	out.Reset()
+	opts.unmark = true
+	if err := runAnswer(context.Background(), opts, []string{"DC_parent"}); err != nil {
+		t.Fatalf("runAnswer() error = %v", err)
+	}
+	if got, want := out.String(), "Unmarked answer to discussion #1 (How do I deploy?)\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestRunAnswerInvalidInput(t *testing.T) {
+	fake, repo := newTestFake(t)
+	general := repo.Discussion(2)
+	general.Comments.Nodes = append(general.Comments.Nodes, models.Comment{ID: "DC_general", DatabaseID: 300})
+
+	tests := []struct {
+		name string
+		ref  string
+	}{
+		{name: "reply", ref: "DC_reply"},
+		{name: "category without answers", ref: "DC_general"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &answerOptions{newClient: fake.Factory(), out: &bytes.Buffer{}}
+			err := runAnswer(context.Background(), opts, []string{tt.ref})
+			if !errors.Is(err, client.ErrInvalidInput) {
+				t.Errorf("runAnswer() error = %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
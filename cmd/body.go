@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// readBody resolves a markdown body from either the --body flag or the
+// --body-file flag, where a body file of "-" reads from standard input
+func readBody(body, bodyFile string) (string, error) {
+	if bodyFile == "" {
+		return body, nil
+	}
+
+	var content []byte
+	var err error
+	if bodyFile == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(bodyFile)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read body file: %w", err)
+	}
+
+	return string(content), nil
+}
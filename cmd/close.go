@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+	"github.com/harakeishi/gh-discussion/pkg/models"
+)
+
+// closeOptions holds the options for the close command
+type closeOptions struct {
+	newClient client.Factory
+	out       io.Writer
+	repo      string
+	reason    string
+}
+
+// NewCloseCmd creates the close command
+func NewCloseCmd(f client.Factory) *cobra.Command {
+	opts := &closeOptions{newClient: f}
+
+	cmd := &cobra.Command{
+		Use:   "close {<number> | <url>}",
+		Short: "Close a discussion",
+		Long: `Close a discussion.
+
+The reason can be one of "resolved", "outdated", or "duplicate".`,
+		Example: `  # Close discussion #123 as resolved
+  gh discussion close 123
+
+  # Close discussion #123 as outdated
+  gh discussion close 123 --reason outdated`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.out = cmd.OutOrStdout()
+			return runClose(cmd.Context(), opts, args[0])
+		},
+	}
+
+	// Repository options
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Select another repository using the [HOST/]OWNER/REPO format")
+
+	// Close options
+	cmd.Flags().StringVarP(&opts.reason, "reason", "r", "resolved", "Reason for closing: {resolved|outdated|duplicate}")
+
+	return cmd
+}
+
+// runClose executes the close command
+func runClose(ctx context.Context, opts *closeOptions, discussionArg string) error {
+	// Validate close reason
+	reason := strings.ToUpper(opts.reason)
+	switch reason {
+	case "RESOLVED", "OUTDATED", "DUPLICATE":
+	default:
+		return invalidInput("invalid value for --reason: %s (expected resolved/outdated/duplicate)", opts.reason)
+	}
+
+	// Parse discussion argument (number or URL)
+	repo, number, err := parseDiscussionArg(discussionArg, opts.repo)
+	if err != nil {
+		return fmt.Errorf("failed to parse discussion argument: %w", err)
+	}
+
+	// Create GitHub client
+	client, err := opts.newClient(repo.Host)
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	discussion, err := client.GetDiscussionWithContext(ctx, models.ViewOptions{
+		Owner:  repo.Owner,
+		Repo:   repo.Name,
+		Number: number,
+		Fields: []string{"number", "title", "closed"},
+	})
+	if err != nil {
+		return err
+	}
+
+	if discussion.Closed {
+		fmt.Fprintf(opts.out, "Discussion #%d (%s) is already closed\n", discussion.Number, discussion.Title)
+		return nil
+	}
+
+	if _, err := client.CloseDiscussionWithContext(ctx, discussion.ID, reason); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.out, "Closed discussion #%d (%s) as %s\n", discussion.Number, discussion.Title, strings.ToLower(reason))
+
+	return nil
+}
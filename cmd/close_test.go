@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestRunCloseAndReopen(t *testing.T) {
+	fake, repo := newTestFake(t)
+
+	var out bytes.Buffer
+	closeOpts := &closeOptions{newClient: fake.Factory(), out: &out, repo: "octo/hello", reason: "outdated"}
+	if err := runClose(context.Background(), closeOpts, "1"); err != nil {
+		t.Fatalf("runClose() error = %v", err)
+	}
+	if got, want := out.String(), "Closed discussion #1 (How do I deploy?) as outdated\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+	if d := repo.Discussion(1); !d.Closed || d.StateReason == nil || *d.StateReason != "OUTDATED" {
+		t.Errorf("discussion closed = %v, state reason = %v, want closed as OUTDATED", d.Closed, d.StateReason)
+	}
+
+	out.Reset()
+	if err := runClose(context.Background(), closeOpts, "1"); err != nil {
+		t.Fatalf("runClose() error = %v", err)
+	}
+	if got, want := out.String(), "Discussion #1 (How do I deploy?) is already closed\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	out.Reset()
+	reopenOpts := &reopenOptions{newClient: fake.Factory(), out: &out, repo: "octo/hello"}
+	if err := runReopen(context.Background(), reopenOpts, "1"); err != nil {
+		t.Fatalf("runReopen() error = %v", err)
+	}
+	if got, want := out.String(), "Reopened discussion #1 (How do I deploy?)\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+	if repo.Discussion(1).Closed {
+		t.Error("discussion is still closed after reopening")
+	}
+}
+
+func TestRunLockAndUnlock(t *testing.T) {
+	fake, repo := newTestFake(t)
+
+	var out bytes.Buffer
+	opts := &lockOptions{newClient: fake.Factory(), out: &out, repo: "octo/hello", reason: "too_heated"}
+	if err := runLock(context.Background(), opts, "1"); err != nil {
+		t.Fatalf("runLock() error = %v", err)
+	}
+	if got, want := out.String(), "Locked discussion #1 (How do I deploy?) as too_heated\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+	if !repo.Discussion(1).Locked {
+		t.Error("discussion is not locked")
+	}
+
+	out.Reset()
+	if err := runUnlock(context.Background(), opts, "1"); err != nil {
+		t.Fatalf("runUnlock() error = %v", err)
+	}
+	if got, want := out.String(), "Unlocked discussion #1 (How do I deploy?)\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+	if repo.Discussion(1).Locked {
+		t.Error("discussion is still locked")
+	}
+}
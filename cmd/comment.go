@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+	"github.com/harakeishi/gh-discussion/pkg/models"
+	"github.com/harakeishi/gh-discussion/pkg/prompt"
+)
+
+// commentOptions holds the options for the comment command
+type commentOptions struct {
+	newClient client.Factory
+	out       io.Writer
+	repo      string
+	body      string
+	bodyFile  string
+	editor    bool
+	replyTo   string
+}
+
+// NewCommentCmd creates the comment command
+func NewCommentCmd(f client.Factory) *cobra.Command {
+	opts := &commentOptions{newClient: f}
+
+	cmd := &cobra.Command{
+		Use:   "comment {<number> | <url>}",
+		Short: "Add a comment to a discussion",
+		Long: `Add a comment to a discussion.
+
+With '--reply-to', the comment is posted as a reply in an existing comment
+thread. The thread can be given as a comment node ID, a comment URL such as
+https://github.com/owner/repo/discussions/123#discussioncomment-456, or the
+numeric ID from that URL. Replying to a reply posts into the same thread.
+
+Without a body flag, the comment is written in your editor when running
+interactively.
+
+Use 'gh discussion comment delete' to delete a comment.`,
+		Example: `  # Comment on discussion #123
+  gh discussion comment 123 --body "Thanks for the report!"
+
+  # Comment with the body read from a file
+  gh discussion comment 123 --body-file answer.md
+
+  # Write the comment in your editor
+  gh discussion comment 123 --editor
+
+  # Reply to a comment thread
+  gh discussion comment 123 --reply-to https://github.com/owner/repo/discussions/123#discussioncomment-456 -b "Fixed in v1.2.0"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.out = cmd.OutOrStdout()
+			return runComment(cmd.Context(), opts, args[0])
+		},
+	}
+
+	// Repository options
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Select another repository using the [HOST/]OWNER/REPO format")
+
+	// Comment options
+	cmd.Flags().StringVarP(&opts.body, "body", "b", "", "Body for the comment")
+	cmd.Flags().StringVarP(&opts.bodyFile, "body-file", "F", "", "Read body text from file (use \"-\" to read from standard input)")
+	cmd.Flags().BoolVarP(&opts.editor, "editor", "e", false, "Write the comment body in your editor")
+	cmd.Flags().StringVar(&opts.replyTo, "reply-to", "", "Reply to the thread of a comment given by ID or URL")
+
+	// Mark mutually exclusive flags
+	cmd.MarkFlagsMutuallyExclusive("body", "body-file", "editor")
+
+	// Add subcommands
+	cmd.AddCommand(newCommentDeleteCmd(f))
+
+	return cmd
+}
+
+// runComment executes the comment command
+func runComment(ctx context.Context, opts *commentOptions, discussionArg string) error {
+	// Parse discussion argument (number or URL)
+	repo, number, err := parseDiscussionArg(discussionArg, opts.repo)
+	if err != nil {
+		return fmt.Errorf("failed to parse discussion argument: %w", err)
+	}
+
+	// Read body from flag, file, or editor
+	body, err := readBody(opts.body, opts.bodyFile)
+	if err != nil {
+		return err
+	}
+
+	if opts.editor || (body == "" && opts.bodyFile == "") {
+		if !prompt.IsInteractive() {
+			return invalidInput("--body or --body-file is required when not running interactively")
+		}
+		body, err = prompt.Editor(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("comment body cannot be blank")
+	}
+
+	// Create GitHub client
+	client, err := opts.newClient(repo.Host)
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	discussionID, err := client.GetDiscussionIDWithContext(ctx, repo.Owner, repo.Name, number)
+	if err != nil {
+		return err
+	}
+
+	commentOpts := models.CommentOptions{
+		DiscussionID: discussionID,
+		Body:         body,
+	}
+
+	if opts.replyTo != "" {
+		commentOpts.ReplyToID, err = resolveReplyTo(ctx, client, repo, number, opts.replyTo)
+		if err != nil {
+			return err
+		}
+	}
+
+	comment, err := client.AddDiscussionCommentWithContext(ctx, commentOpts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(opts.out, comment.URL)
+
+	return nil
+}
+
+// resolveReplyTo resolves a comment ID or URL to the node ID of the top-level
+// comment whose thread a reply should be posted in
+func resolveReplyTo(ctx context.Context, c client.DiscussionAPI, repo *Repository, number int, ref string) (string, error) {
+	var databaseID int
+
+	switch {
+	case isURL(ref):
+		commentRepo, commentNumber, id, err := parseCommentURL(ref)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse --reply-to: %w", err)
+		}
+		if !strings.EqualFold(commentRepo.Host, repo.Host) || !strings.EqualFold(commentRepo.Owner, repo.Owner) || !strings.EqualFold(commentRepo.Name, repo.Name) || commentNumber != number {
+			return "", fmt.Errorf("comment %s does not belong to discussion #%d", ref, number)
+		}
+		databaseID = id
+	default:
+		id, err := strconv.Atoi(ref)
+		if err != nil {
+			// Treat anything else as a comment node ID
+			return resolveReplyToNode(ctx, c, repo, number, ref)
+		}
+		databaseID = id
+	}
+
+	comment, err := c.FindDiscussionCommentWithContext(ctx, repo.Owner, repo.Name, number, databaseID)
+	if err != nil {
+		return "", err
+	}
+
+	// Discussion threads are one level deep, so replies to a reply go to its parent
+	if comment.ReplyTo != nil {
+		return comment.ReplyTo.ID, nil
+	}
+	return comment.ID, nil
+}
+
+// resolveReplyToNode looks up a comment node ID and returns the node ID of the
+// top-level comment whose thread it belongs to
+func resolveReplyToNode(ctx context.Context, c client.DiscussionAPI, repo *Repository, number int, id string) (string, error) {
+	comment, err := c.GetDiscussionCommentWithContext(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	// Node IDs are global, so the comment may be in another repository
+	if d := comment.Discussion; d != nil {
+		sameRepo := d.Repository != nil && d.Repository.Owner != nil &&
+			strings.EqualFold(d.Repository.Owner.Login, repo.Owner) && strings.EqualFold(d.Repository.Name, repo.Name)
+		if !sameRepo || d.Number != number {
+			return "", fmt.Errorf("comment %s does not belong to discussion #%d", id, number)
+		}
+	}
+	if comment.ReplyTo != nil {
+		return comment.ReplyTo.ID, nil
+	}
+	return comment.ID, nil
+}
+
+// resolveCommentID resolves a comment URL or node ID to the comment's node ID
+func resolveCommentID(ctx context.Context, c client.DiscussionAPI, ref string) (string, error) {
+	if !isURL(ref) {
+		return ref, nil
+	}
+
+	repo, number, databaseID, err := parseCommentURL(ref)
+	if err != nil {
+		return "", err
+	}
+
+	comment, err := c.FindDiscussionCommentWithContext(ctx, repo.Owner, repo.Name, number, databaseID)
+	if err != nil {
+		return "", err
+	}
+	return comment.ID, nil
+}
+
+// commentHost returns the host of a comment URL, or an empty string for the
+// default host when ref is a node ID
+func commentHost(ref string) string {
+	if !isURL(ref) {
+		return ""
+	}
+	repo, _, _, err := parseCommentURL(ref)
+	if err != nil {
+		return ""
+	}
+	return repo.Host
+}
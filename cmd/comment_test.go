@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harakeishi/gh-discussion/pkg/models"
+)
+
+func TestResolveReplyTo(t *testing.T) {
+	fake, _ := newTestFake(t)
+	repo := &Repository{Host: "github.com", Owner: "octo", Name: "hello"}
+
+	tests := []struct {
+		name string
+		ref  string
+		want string
+	}{
+		{name: "comment ID", ref: "100", want: "DC_parent"},
+		{name: "reply ID", ref: "101", want: "DC_parent"},
+		{name: "comment URL", ref: "https://github.com/octo/hello/discussions/1#discussioncomment-100", want: "DC_parent"},
+		{name: "reply URL", ref: "https://github.com/octo/hello/discussions/1#discussioncomment-101", want: "DC_parent"},
+		{name: "comment node ID", ref: "DC_parent", want: "DC_parent"},
+		{name: "reply node ID", ref: "DC_reply", want: "DC_parent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveReplyTo(context.Background(), fake, repo, 1, tt.ref)
+			if err != nil {
+				t.Fatalf("resolveReplyTo(%q) error = %v", tt.ref, err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveReplyTo(%q) = %q, want %q", tt.ref, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := resolveReplyTo(context.Background(), fake, repo, 2, "DC_reply"); err == nil {
+		t.Error("resolveReplyTo() accepted a comment from another discussion")
+	}
+
+	other := fake.AddRepository("octo", "other")
+	other.AddDiscussion(models.Discussion{
+		Title: "Elsewhere",
+		Comments: &models.CommentConnection{
+			Nodes: []models.Comment{{ID: "DC_other", DatabaseID: 200}},
+		},
+	})
+	if _, err := resolveReplyTo(context.Background(), fake, repo, 1, "DC_other"); err == nil {
+		t.Error("resolveReplyTo() accepted a comment from another repository")
+	}
+}
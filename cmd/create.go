@@ -1,39 +1,71 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+	"github.com/harakeishi/gh-discussion/pkg/forms"
+	"github.com/harakeishi/gh-discussion/pkg/models"
+	"github.com/harakeishi/gh-discussion/pkg/prompt"
 )
 
 // createOptions holds the options for the create command
 type createOptions struct {
-	repo     string
-	title    string
-	body     string
-	category string
-	web      bool
+	newClient client.Factory
+	out       io.Writer
+	repo      string
+	title     string
+	body      string
+	bodyFile  string
+	category  string
+	fields    []string
+	web       bool
 }
 
 // NewCreateCmd creates the create command
-func NewCreateCmd() *cobra.Command {
-	opts := &createOptions{}
+func NewCreateCmd(f client.Factory) *cobra.Command {
+	opts := &createOptions{newClient: f}
 
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a new discussion",
 		Long: `Create a new discussion in a repository.
 
-This command will open an interactive prompt to gather the required information
-for creating a discussion, including title, body, and category.`,
+When run in a terminal, any of the title, category, or body that were not
+given as flags are gathered through an interactive prompt. The body is written
+in the editor configured by GH_EDITOR, the gh "editor" setting, VISUAL, or EDITOR.
+
+If the chosen category has a discussion form template in
+.github/DISCUSSION_TEMPLATE/<category-slug>.yml, the body is built from the
+template's fields instead. Field values can be given with --field using the
+field id (or label when it has no id); any missing fields are prompted for.
+Multiple dropdown or checkbox options are separated by commas or given by
+repeating --field. Labels listed in the template are added to the discussion.
+
+When not running interactively, --title and --category are required, as well
+as --body unless the category has a template.`,
 		Example: `  # Create a discussion interactively
   gh discussion create
 
-  # Create a discussion with title and body
-  gh discussion create --title "Discussion Title" --body "Discussion body"
+  # Create a discussion non-interactively
+  gh discussion create --title "Discussion Title" --body "Discussion body" --category "General"
 
-  # Create a discussion in a specific category
-  gh discussion create --category "General"
+  # Create a discussion with the body read from a file
+  gh discussion create --title "Release v1.2.0" --category "Announcements" --body-file notes.md
+
+  # Create a discussion with the body read from standard input
+  cat notes.md | gh discussion create --title "Release v1.2.0" --category "Announcements" -F -
+
+  # Fill in the fields of the category's discussion template
+  gh discussion create --title "Crash on startup" --category "Bug report" \
+    --field version=1.2.0 --field os=Linux --field "what-happened=It crashes"
 
   # Create a discussion in a specific repository
   gh discussion create -R owner/repo
@@ -41,7 +73,8 @@ for creating a discussion, including title, body, and category.`,
   # Open the discussion creation form in web browser
   gh discussion create -w`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runCreate(opts)
+			opts.out = cmd.OutOrStdout()
+			return runCreate(cmd.Context(), opts)
 		},
 	}
 
@@ -50,17 +83,22 @@ for creating a discussion, including title, body, and category.`,
 
 	// Discussion options
 	cmd.Flags().StringVar(&opts.title, "title", "", "Title for the discussion")
-	cmd.Flags().StringVar(&opts.body, "body", "", "Body for the discussion")
-	cmd.Flags().StringVar(&opts.category, "category", "", "Category for the discussion")
+	cmd.Flags().StringVarP(&opts.body, "body", "b", "", "Body for the discussion")
+	cmd.Flags().StringVarP(&opts.bodyFile, "body-file", "F", "", "Read body text from file (use \"-\" to read from standard input)")
+	cmd.Flags().StringVar(&opts.category, "category", "", "Category for the discussion, by name or slug")
+	cmd.Flags().StringArrayVar(&opts.fields, "field", nil, "Set a discussion template field in key=value format")
 
 	// Output options
 	cmd.Flags().BoolVarP(&opts.web, "web", "w", false, "Open the discussion creation form in the web browser")
 
+	// Mark mutually exclusive flags
+	cmd.MarkFlagsMutuallyExclusive("body", "body-file")
+
 	return cmd
 }
 
 // runCreate executes the create command
-func runCreate(opts *createOptions) error {
+func runCreate(ctx context.Context, opts *createOptions) error {
 	// Parse repository
 	repo, err := parseRepository(opts.repo)
 	if err != nil {
@@ -69,12 +107,349 @@ func runCreate(opts *createOptions) error {
 
 	// Handle web browser option
 	if opts.web {
-		return openInBrowser(fmt.Sprintf("https://github.com/%s/%s/discussions/new", repo.Owner, repo.Name))
+		return openInBrowser(opts.out, repo.URL()+"/discussions/new")
+	}
+
+	// Read body from file or standard input
+	opts.body, err = readBody(opts.body, opts.bodyFile)
+	if err != nil {
+		return err
+	}
+
+	fieldValues, err := forms.ParseFieldFlags(opts.fields)
+	if err != nil {
+		return invalidInput("%w", err)
+	}
+
+	canPrompt := prompt.IsInteractive()
+	if (opts.title == "" || opts.category == "") && !canPrompt {
+		return invalidInput("--title and --category are required when not running interactively")
+	}
+
+	// Create GitHub client
+	client, err := opts.newClient(repo.Host)
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	// Resolve repository ID
+	repoInfo, err := client.GetRepositoryInfoWithContext(ctx, repo.Owner, repo.Name)
+	if err != nil {
+		return err
+	}
+	if !repoInfo.HasDiscussionsEnabled {
+		return discussionsDisabled(repoInfo.NameWithOwner)
+	}
+
+	// Resolve category ID
+	categories, err := client.GetDiscussionCategoriesWithContext(ctx, repo.Owner, repo.Name)
+	if err != nil {
+		return err
+	}
+
+	// Prompt for anything that was not given as a flag
+	prompted := false
+
+	var category *models.Category
+	if opts.category == "" {
+		category, err = promptCategory(categories)
+		prompted = true
+	} else {
+		category, err = findCategory(categories, opts.category)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Load the category's discussion form template, if any
+	var tmpl *forms.Template
+	if opts.body == "" && opts.bodyFile == "" {
+		data, err := client.GetDiscussionTemplateWithContext(ctx, repo.Owner, repo.Name, category.Slug)
+		if err != nil {
+			return err
+		}
+		if data != nil {
+			tmpl, err = forms.Parse(data)
+			if err != nil {
+				return invalidInput("%w", err)
+			}
+		}
+	}
+	if tmpl == nil && len(fieldValues) > 0 {
+		return invalidInput("--field can only be used when category %q has a discussion template", category.Name)
+	}
+	if tmpl != nil {
+		fieldValues = tmpl.SplitFlagValues(fieldValues)
+	}
+
+	if opts.title == "" {
+		defaultTitle := ""
+		if tmpl != nil {
+			defaultTitle = tmpl.Title
+		}
+		opts.title, err = prompt.Input("Title", defaultTitle)
+		if err != nil {
+			return err
+		}
+		if opts.title == "" {
+			return fmt.Errorf("title cannot be blank")
+		}
+		prompted = true
+	}
+
+	switch {
+	case tmpl != nil:
+		if canPrompt {
+			promptedFields, err := promptTemplateFields(tmpl, fieldValues)
+			if err != nil {
+				return err
+			}
+			prompted = prompted || promptedFields
+		}
+		opts.body, err = tmpl.RenderBody(fieldValues)
+		if err != nil {
+			return invalidInput("%w", err)
+		}
+	case opts.body == "":
+		if !canPrompt {
+			return invalidInput("--body or --body-file is required when not running interactively")
+		}
+		opts.body, err = prompt.Editor("")
+		if err != nil {
+			return err
+		}
+		prompted = true
+	}
+
+	if prompted {
+		submit, err := confirmSubmit(opts, category)
+		if err != nil {
+			return err
+		}
+		if !submit {
+			return cancelled("discussion creation cancelled")
+		}
+	}
+
+	if opts.body == "" {
+		return fmt.Errorf("body cannot be blank")
+	}
+
+	// Resolve the template's labels before creating anything
+	var labelIDs []string
+	if tmpl != nil {
+		labelIDs, err = templateLabelIDs(ctx, client, repo, tmpl.Labels)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Create the discussion
+	discussion, err := client.CreateDiscussionWithContext(ctx, models.CreateOptions{
+		RepositoryID: repoInfo.ID,
+		CategoryID:   category.ID,
+		Title:        opts.title,
+		Body:         opts.body,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Apply the template's labels like the web form does
+	if len(labelIDs) > 0 {
+		if err := client.AddLabelsWithContext(ctx, discussion.ID, labelIDs); err != nil {
+			return fmt.Errorf("created %s but failed to add labels: %w", discussion.URL, err)
+		}
 	}
 
-	// For now, just return a message indicating this feature is not yet implemented
-	fmt.Println("Discussion creation via CLI is not yet implemented.")
-	fmt.Printf("You can create a discussion in the web browser at: https://github.com/%s/%s/discussions/new\n", repo.Owner, repo.Name)
+	fmt.Fprintln(opts.out, discussion.URL)
 
 	return nil
 }
+
+// templateLabelIDs resolves the labels of a discussion template. Like the web
+// form, labels that do not exist in the repository are skipped.
+func templateLabelIDs(ctx context.Context, c client.DiscussionAPI, repo *Repository, names []string) ([]string, error) {
+	var ids []string
+	for _, name := range names {
+		labelIDs, err := c.GetLabelIDsWithContext(ctx, repo.Owner, repo.Name, []string{name})
+		if errors.Is(err, client.ErrNotFound) {
+			fmt.Fprintf(os.Stderr, "Skipping label %q, which does not exist in %s/%s\n", name, repo.Owner, repo.Name)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, labelIDs...)
+	}
+	return ids, nil
+}
+
+// promptCategory asks the user to pick one of the repository's discussion categories
+func promptCategory(categories []models.Category) (*models.Category, error) {
+	if len(categories) == 0 {
+		return nil, fmt.Errorf("no discussion categories found in this repository")
+	}
+
+	options := make([]string, len(categories))
+	for i, category := range categories {
+		options[i] = formatCategoryOption(category)
+	}
+
+	index, err := prompt.Select("Category", options)
+	if err != nil {
+		return nil, err
+	}
+	return &categories[index], nil
+}
+
+// formatCategoryOption renders a category for the category picker
+func formatCategoryOption(category models.Category) string {
+	option := category.Name
+	if emoji := categoryEmoji(category); emoji != "" {
+		option = emoji + " " + option
+	}
+	if category.IsAnswerable {
+		option += " (answerable)"
+	}
+	if category.Description != "" {
+		option += " - " + category.Description
+	}
+	return option
+}
+
+// categoryEmoji extracts the rendered emoji from the category's emoji HTML,
+// falling back to the emoji shortcode
+func categoryEmoji(category models.Category) string {
+	if category.EmojiHTML == "" {
+		return category.Emoji
+	}
+
+	var b strings.Builder
+	inTag := false
+	for _, r := range category.EmojiHTML {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>':
+			inTag = false
+		case !inTag:
+			b.WriteRune(r)
+		}
+	}
+
+	if emoji := strings.TrimSpace(b.String()); emoji != "" {
+		return emoji
+	}
+	return category.Emoji
+}
+
+// promptTemplateFields asks for every template field that was not given with
+// --field, storing the answers in values. It reports whether any prompt was shown.
+func promptTemplateFields(tmpl *forms.Template, values map[string][]string) (bool, error) {
+	prompted := false
+	for _, field := range tmpl.Inputs() {
+		if _, ok := values[field.Key()]; ok {
+			continue
+		}
+
+		message := field.Attributes.Label
+		if field.Validations.Required {
+			message += " (required)"
+		}
+		if field.Attributes.Description != "" {
+			fmt.Fprintln(os.Stderr, field.Attributes.Description)
+		}
+
+		var value []string
+		var err error
+		switch field.Type {
+		case forms.FieldInput:
+			var text string
+			text, err = prompt.Input(message, field.DefaultValue())
+			value = []string{text}
+		case forms.FieldTextarea:
+			// Like the web form, only a value prefills the field; the
+			// placeholder is a hint and is never submitted
+			fmt.Fprintf(os.Stderr, "%s: opening editor\n", message)
+			var text string
+			text, err = prompt.Editor(field.DefaultValue())
+			value = []string{text}
+		case forms.FieldDropdown, forms.FieldCheckboxes:
+			value, err = promptOptions(message, field)
+		}
+		if err != nil {
+			return prompted, err
+		}
+
+		values[field.Key()] = value
+		prompted = true
+	}
+	return prompted, nil
+}
+
+// promptOptions asks the user to pick the options of a dropdown or checkboxes
+// field and returns the labels of the selected options
+func promptOptions(message string, field forms.Field) ([]string, error) {
+	options := field.OptionLabels()
+
+	if field.Type == forms.FieldDropdown && !field.Attributes.Multiple {
+		if !field.Validations.Required {
+			options = append([]string{"None"}, options...)
+		}
+		index, err := prompt.Select(message, options)
+		if err != nil {
+			return nil, err
+		}
+		if !field.Validations.Required {
+			if index == 0 {
+				return nil, nil
+			}
+			index--
+		}
+		return []string{field.Attributes.Options[index].Label}, nil
+	}
+
+	var defaults []int
+	if d := field.Attributes.Default; d != nil {
+		defaults = append(defaults, *d)
+	}
+
+	indices, err := prompt.MultiSelect(message, options, defaults)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make([]string, len(indices))
+	for i, index := range indices {
+		selected[i] = options[index]
+	}
+	return selected, nil
+}
+
+// confirmSubmit asks the user whether to submit, preview, or cancel the discussion
+func confirmSubmit(opts *createOptions, category *models.Category) (bool, error) {
+	for {
+		choice, err := prompt.Select("What's next?", []string{"Submit", "Preview", "Cancel"})
+		if err != nil {
+			return false, err
+		}
+
+		switch choice {
+		case 0:
+			return true, nil
+		case 1:
+			fmt.Fprintf(os.Stderr, "\n%s\n", opts.title)
+			fmt.Fprintf(os.Stderr, "Category: %s\n\n", category.Name)
+			fmt.Fprintf(os.Stderr, "%s\n\n", opts.body)
+		default:
+			return false, nil
+		}
+	}
+}
+
+// findCategory looks up a discussion category by name or slug, suggesting
+// the closest category when there is no match
+func findCategory(categories []models.Category, name string) (*models.Category, error) {
+	return client.FindCategory(categories, name)
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+)
+
+const createTestTemplate = `
+labels: [question, triage]
+body:
+  - type: textarea
+    id: details
+    attributes:
+      label: Details
+      placeholder: Describe your question
+    validations:
+      required: true
+  - type: dropdown
+    id: area
+    attributes:
+      label: Area
+      options: [CLI, API]
+`
+
+func TestRunCreateTemplate(t *testing.T) {
+	fake, repo := newTestFake(t)
+	repo.Templates["q-a"] = createTestTemplate
+	repo.AddLabel("question", "d876e3")
+
+	var out bytes.Buffer
+	opts := &createOptions{
+		newClient: fake.Factory(),
+		out:       &out,
+		repo:      "octo/hello",
+		title:     "Which area?",
+		category:  "Q&A",
+		fields:    []string{"details=How do I log in?", "area=CLI"},
+	}
+	if err := runCreate(context.Background(), opts); err != nil {
+		t.Fatalf("runCreate() error = %v", err)
+	}
+
+	d := repo.Discussion(3)
+	if d == nil {
+		t.Fatal("discussion #3 was not created")
+	}
+	if want := "### Details\n\nHow do I log in?\n\n### Area\n\nCLI"; d.Body != want {
+		t.Errorf("body = %q, want %q", d.Body, want)
+	}
+	if d.Labels == nil || len(d.Labels.Nodes) != 1 || d.Labels.Nodes[0].Name != "question" {
+		t.Errorf("labels = %+v, want only the existing template label question", d.Labels)
+	}
+	if got := strings.TrimSpace(out.String()); got != d.URL {
+		t.Errorf("output = %q, want %q", got, d.URL)
+	}
+}
+
+func TestRunCreateTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		fields   []string
+	}{
+		{name: "malformed field flag", template: createTestTemplate, fields: []string{"details"}},
+		{name: "missing required field", template: createTestTemplate, fields: []string{"area=CLI"}},
+		{name: "unknown option", template: createTestTemplate, fields: []string{"details=x", "area=GUI"}},
+		{name: "unknown field", template: createTestTemplate, fields: []string{"details=x", "nope=y"}},
+		{name: "invalid template", template: "body:\n  - type: slider\n", fields: []string{"details=x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, repo := newTestFake(t)
+			repo.Templates["q-a"] = tt.template
+
+			opts := &createOptions{
+				newClient: fake.Factory(),
+				out:       &bytes.Buffer{},
+				repo:      "octo/hello",
+				title:     "Title",
+				category:  "Q&A",
+				fields:    tt.fields,
+			}
+			err := runCreate(context.Background(), opts)
+			if !errors.Is(err, client.ErrInvalidInput) {
+				t.Errorf("runCreate() error = %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+	"github.com/harakeishi/gh-discussion/pkg/models"
+	"github.com/harakeishi/gh-discussion/pkg/prompt"
+)
+
+// deleteOptions holds the options for the delete commands
+type deleteOptions struct {
+	newClient client.Factory
+	out       io.Writer
+	repo      string
+	yes       bool
+}
+
+// NewDeleteCmd creates the delete command
+func NewDeleteCmd(f client.Factory) *cobra.Command {
+	opts := &deleteOptions{newClient: f}
+
+	cmd := &cobra.Command{
+		Use:   "delete {<number> | <url>}",
+		Short: "Delete a discussion",
+		Long: `Delete a discussion.
+
+When running interactively, you are asked to type the discussion number to
+confirm. Otherwise, '--yes' is required.`,
+		Example: `  # Delete discussion #123
+  gh discussion delete 123
+
+  # Delete discussion #123 without confirmation
+  gh discussion delete 123 --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.out = cmd.OutOrStdout()
+			return runDelete(cmd.Context(), opts, args[0])
+		},
+	}
+
+	// Repository options
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Select another repository using the [HOST/]OWNER/REPO format")
+
+	// Delete options
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Confirm deletion without prompting")
+
+	return cmd
+}
+
+// newCommentDeleteCmd creates the comment delete command
+func newCommentDeleteCmd(f client.Factory) *cobra.Command {
+	opts := &deleteOptions{newClient: f}
+
+	cmd := &cobra.Command{
+		Use:   "delete {<comment-url> | <comment-id>}",
+		Short: "Delete a discussion comment",
+		Long: `Delete a discussion comment.
+
+When running interactively, you are asked to type the number of the discussion
+the comment belongs to. Otherwise, '--yes' is required.`,
+		Example: `  # Delete a comment
+  gh discussion comment delete https://github.com/owner/repo/discussions/123#discussioncomment-456
+
+  # Delete a comment without confirmation
+  gh discussion comment delete DC_kwDOAbc123 --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.out = cmd.OutOrStdout()
+			return runCommentDelete(cmd.Context(), opts, args[0])
+		},
+	}
+
+	// Delete options
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Confirm deletion without prompting")
+
+	return cmd
+}
+
+// runDelete executes the delete command
+func runDelete(ctx context.Context, opts *deleteOptions, discussionArg string) error {
+	if !opts.yes && !prompt.IsInteractive() {
+		return invalidInput("--yes is required when not running interactively")
+	}
+
+	// Parse discussion argument (number or URL)
+	repo, number, err := parseDiscussionArg(discussionArg, opts.repo)
+	if err != nil {
+		return fmt.Errorf("failed to parse discussion argument: %w", err)
+	}
+
+	// Create GitHub client
+	client, err := opts.newClient(repo.Host)
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	discussion, err := client.GetDiscussionWithContext(ctx, models.ViewOptions{
+		Owner:  repo.Owner,
+		Repo:   repo.Name,
+		Number: number,
+		Fields: []string{"number", "title", "viewerCanDelete"},
+	})
+	if err != nil {
+		return err
+	}
+
+	if !discussion.ViewerCanDelete {
+		return forbidden("you do not have permission to delete discussion #%d in %s/%s", number, repo.Owner, repo.Name)
+	}
+
+	if !opts.yes {
+		fmt.Fprintf(os.Stderr, "You are about to delete discussion #%d (%s).\n", discussion.Number, discussion.Title)
+		if err := confirmDeletion(discussion.Number); err != nil {
+			return err
+		}
+	}
+
+	if err := client.DeleteDiscussionWithContext(ctx, discussion.ID); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.out, "Deleted discussion #%d (%s)\n", discussion.Number, discussion.Title)
+
+	return nil
+}
+
+// runCommentDelete executes the comment delete command
+func runCommentDelete(ctx context.Context, opts *deleteOptions, commentArg string) error {
+	if !opts.yes && !prompt.IsInteractive() {
+		return invalidInput("--yes is required when not running interactively")
+	}
+
+	// Create GitHub client
+	client, err := opts.newClient(commentHost(commentArg))
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	commentID, err := resolveCommentID(ctx, client, commentArg)
+	if err != nil {
+		return err
+	}
+
+	comment, err := client.GetDiscussionCommentWithContext(ctx, commentID)
+	if err != nil {
+		return err
+	}
+
+	if comment.Discussion == nil {
+		return fmt.Errorf("comment %s does not belong to a discussion", commentID)
+	}
+
+	if !comment.ViewerCanDelete {
+		return forbidden("you do not have permission to delete comment %s", commentID)
+	}
+
+	if !opts.yes {
+		fmt.Fprintf(os.Stderr, "You are about to delete a comment on discussion #%d (%s).\n", comment.Discussion.Number, comment.Discussion.Title)
+		if err := confirmDeletion(comment.Discussion.Number); err != nil {
+			return err
+		}
+	}
+
+	if err := client.DeleteDiscussionCommentWithContext(ctx, comment.ID); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.out, "Deleted comment on discussion #%d (%s)\n", comment.Discussion.Number, comment.Discussion.Title)
+
+	return nil
+}
+
+// confirmDeletion asks the user to type the discussion number to confirm
+func confirmDeletion(number int) error {
+	answer, err := prompt.Input(fmt.Sprintf("Type %d to confirm deletion:", number), "")
+	if err != nil {
+		return err
+	}
+	if answer != strconv.Itoa(number) {
+		return cancelled("confirmation did not match, nothing was deleted")
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+)
+
+func TestRunDelete(t *testing.T) {
+	fake, repo := newTestFake(t)
+	repo.Discussion(1).ViewerCanDelete = true
+
+	var out bytes.Buffer
+	opts := &deleteOptions{newClient: fake.Factory(), out: &out, repo: "octo/hello", yes: true}
+	if err := runDelete(context.Background(), opts, "1"); err != nil {
+		t.Fatalf("runDelete() error = %v", err)
+	}
+	if got, want := out.String(), "Deleted discussion #1 (How do I deploy?)\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+	if repo.Discussion(1) != nil {
+		t.Error("discussion #1 still exists")
+	}
+
+	if err := runDelete(context.Background(), opts, "2"); !errors.Is(err, client.ErrForbidden) {
+		t.Errorf("runDelete() error = %v, want ErrForbidden", err)
+	}
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+	"github.com/harakeishi/gh-discussion/pkg/models"
+	"github.com/harakeishi/gh-discussion/pkg/prompt"
+)
+
+// editOptions holds the options for the edit command
+type editOptions struct {
+	newClient    client.Factory
+	out          io.Writer
+	repo         string
+	title        string
+	body         string
+	bodyFile     string
+	editor       bool
+	category     string
+	addLabels    []string
+	removeLabels []string
+}
+
+// NewEditCmd creates the edit command
+func NewEditCmd(f client.Factory) *cobra.Command {
+	opts := &editOptions{newClient: f}
+
+	cmd := &cobra.Command{
+		Use:   "edit {<number> | <url>}",
+		Short: "Edit a discussion",
+		Long: `Edit the title, body, category, or labels of a discussion.
+
+With '--editor', the current body of the discussion is opened in your editor.`,
+		Example: `  # Change the title of discussion #123
+  gh discussion edit 123 --title "New title"
+
+  # Edit the body in your editor
+  gh discussion edit 123 --editor
+
+  # Move the discussion to another category
+  gh discussion edit 123 --category "Q&A"
+
+  # Add and remove labels
+  gh discussion edit 123 --add-label bug --remove-label question`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.out = cmd.OutOrStdout()
+			return runEdit(cmd.Context(), opts, args[0])
+		},
+	}
+
+	// Repository options
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Select another repository using the [HOST/]OWNER/REPO format")
+
+	// Discussion options
+	cmd.Flags().StringVarP(&opts.title, "title", "t", "", "Set the new title")
+	cmd.Flags().StringVarP(&opts.body, "body", "b", "", "Set the new body")
+	cmd.Flags().StringVarP(&opts.bodyFile, "body-file", "F", "", "Read body text from file (use \"-\" to read from standard input)")
+	cmd.Flags().BoolVarP(&opts.editor, "editor", "e", false, "Edit the body in your editor")
+	cmd.Flags().StringVar(&opts.category, "category", "", "Move the discussion to a category by name or slug")
+	cmd.Flags().StringSliceVar(&opts.addLabels, "add-label", nil, "Add labels by name")
+	cmd.Flags().StringSliceVar(&opts.removeLabels, "remove-label", nil, "Remove labels by name")
+
+	// Mark mutually exclusive flags
+	cmd.MarkFlagsMutuallyExclusive("body", "body-file", "editor")
+
+	return cmd
+}
+
+// runEdit executes the edit command
+func runEdit(ctx context.Context, opts *editOptions, discussionArg string) error {
+	if opts.title == "" && opts.body == "" && opts.bodyFile == "" && !opts.editor && opts.category == "" &&
+		len(opts.addLabels) == 0 && len(opts.removeLabels) == 0 {
+		return invalidInput("specify at least one of --title, --body, --body-file, --editor, --category, --add-label, or --remove-label")
+	}
+
+	// Parse discussion argument (number or URL)
+	repo, number, err := parseDiscussionArg(discussionArg, opts.repo)
+	if err != nil {
+		return fmt.Errorf("failed to parse discussion argument: %w", err)
+	}
+
+	// Read body from flag or file
+	body, err := readBody(opts.body, opts.bodyFile)
+	if err != nil {
+		return err
+	}
+	if opts.bodyFile != "" && strings.TrimSpace(body) == "" {
+		return fmt.Errorf("body cannot be blank")
+	}
+
+	// Create GitHub client
+	client, err := opts.newClient(repo.Host)
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	discussion, err := client.GetDiscussionWithContext(ctx, models.ViewOptions{
+		Owner:  repo.Owner,
+		Repo:   repo.Name,
+		Number: number,
+		Fields: []string{"viewerCanUpdate", "body", "url"},
+	})
+	if err != nil {
+		return err
+	}
+
+	if !discussion.ViewerCanUpdate {
+		return forbidden("you do not have permission to edit discussion #%d in %s/%s", number, repo.Owner, repo.Name)
+	}
+
+	if opts.editor {
+		if !prompt.IsInteractive() {
+			return invalidInput("--editor requires an interactive terminal")
+		}
+		body, err = prompt.Editor(discussion.Body)
+		if err != nil {
+			return err
+		}
+		if body == "" {
+			return fmt.Errorf("body cannot be blank")
+		}
+		if body == strings.TrimSpace(discussion.Body) {
+			body = ""
+		}
+	}
+
+	updateOpts := models.UpdateOptions{
+		DiscussionID: discussion.ID,
+		Title:        opts.title,
+		Body:         body,
+	}
+
+	if opts.category != "" {
+		categories, err := client.GetDiscussionCategoriesWithContext(ctx, repo.Owner, repo.Name)
+		if err != nil {
+			return err
+		}
+		category, err := findCategory(categories, opts.category)
+		if err != nil {
+			return err
+		}
+		updateOpts.CategoryID = category.ID
+	}
+
+	if updateOpts.Title != "" || updateOpts.Body != "" || updateOpts.CategoryID != "" {
+		if _, err := client.UpdateDiscussionWithContext(ctx, updateOpts); err != nil {
+			return err
+		}
+	}
+
+	if len(opts.addLabels) > 0 {
+		labelIDs, err := client.GetLabelIDsWithContext(ctx, repo.Owner, repo.Name, opts.addLabels)
+		if err != nil {
+			return err
+		}
+		if err := client.AddLabelsWithContext(ctx, discussion.ID, labelIDs); err != nil {
+			return err
+		}
+	}
+
+	if len(opts.removeLabels) > 0 {
+		labelIDs, err := client.GetLabelIDsWithContext(ctx, repo.Owner, repo.Name, opts.removeLabels)
+		if err != nil {
+			return err
+		}
+		if err := client.RemoveLabelsWithContext(ctx, discussion.ID, labelIDs); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(opts.out, discussion.URL)
+
+	return nil
+}
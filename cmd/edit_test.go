@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+)
+
+func TestRunEdit(t *testing.T) {
+	fake, repo := newTestFake(t)
+	repo.Discussion(1).ViewerCanUpdate = true
+
+	var out bytes.Buffer
+	opts := &editOptions{newClient: fake.Factory(), out: &out, repo: "octo/hello", title: "How do I deploy to staging?", category: "general"}
+	if err := runEdit(context.Background(), opts, "1"); err != nil {
+		t.Fatalf("runEdit() error = %v", err)
+	}
+
+	d := repo.Discussion(1)
+	if got, want := out.String(), d.URL+"\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+	if d.Title != "How do I deploy to staging?" || d.Category.Name != "General" || d.Body != "Deployment question" {
+		t.Errorf("title = %q, category = %q, body = %q, want a new title and category only", d.Title, d.Category.Name, d.Body)
+	}
+
+	err := runEdit(context.Background(), &editOptions{newClient: fake.Factory(), out: &out, repo: "octo/hello", title: "x"}, "2")
+	if !errors.Is(err, client.ErrForbidden) {
+		t.Errorf("runEdit() error = %v, want ErrForbidden", err)
+	}
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+)
+
+// enableOptions holds the options for the enable and disable commands
+type enableOptions struct {
+	newClient client.Factory
+	out       io.Writer
+	repo      string
+}
+
+// NewEnableCmd creates the enable command
+func NewEnableCmd(f client.Factory) *cobra.Command {
+	opts := &enableOptions{newClient: f}
+
+	cmd := &cobra.Command{
+		Use:   "enable",
+		Short: "Enable discussions for a repository",
+		Long: `Turn on the Discussions feature for a repository.
+
+This requires admin access to the repository.`,
+		Example: `  # Enable discussions in the current repository
+  gh discussion enable
+
+  # Enable discussions in a specific repository
+  gh discussion enable -R owner/repo`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.out = cmd.OutOrStdout()
+			return runSetDiscussionsEnabled(cmd.Context(), opts, true)
+		},
+	}
+
+	// Repository options
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Select another repository using the [HOST/]OWNER/REPO format")
+
+	return cmd
+}
+
+// NewDisableCmd creates the disable command
+func NewDisableCmd(f client.Factory) *cobra.Command {
+	opts := &enableOptions{newClient: f}
+
+	cmd := &cobra.Command{
+		Use:   "disable",
+		Short: "Disable discussions for a repository",
+		Long: `Turn off the Discussions feature for a repository.
+
+Existing discussions are hidden, not deleted, and reappear when discussions are
+enabled again. This requires admin access to the repository.`,
+		Example: `  # Disable discussions in a specific repository
+  gh discussion disable -R owner/repo`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.out = cmd.OutOrStdout()
+			return runSetDiscussionsEnabled(cmd.Context(), opts, false)
+		},
+	}
+
+	// Repository options
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Select another repository using the [HOST/]OWNER/REPO format")
+
+	return cmd
+}
+
+// runSetDiscussionsEnabled executes the enable and disable commands
+func runSetDiscussionsEnabled(ctx context.Context, opts *enableOptions, enabled bool) error {
+	repo, err := parseRepository(opts.repo)
+	if err != nil {
+		return fmt.Errorf("failed to parse repository: %w", err)
+	}
+
+	// Create GitHub client
+	client, err := opts.newClient(repo.Host)
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	repoInfo, err := client.GetRepositoryInfoWithContext(ctx, repo.Owner, repo.Name)
+	if err != nil {
+		return err
+	}
+
+	state := "disabled"
+	if enabled {
+		state = "enabled"
+	}
+
+	if repoInfo.HasDiscussionsEnabled == enabled {
+		fmt.Fprintf(opts.out, "Discussions are already %s for %s\n", state, repoInfo.NameWithOwner)
+		return nil
+	}
+
+	if err := client.SetDiscussionsEnabledWithContext(ctx, repo.Owner, repo.Name, enabled); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.out, "Discussions %s for %s\n", state, repoInfo.NameWithOwner)
+
+	return nil
+}
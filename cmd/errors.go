@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+	"github.com/harakeishi/gh-discussion/pkg/prompt"
+)
+
+// invalidInput returns an error for bad user input that maps to the invalid
+// input exit code
+func invalidInput(format string, args ...interface{}) error {
+	return &client.Error{Kind: client.ErrInvalidInput, Err: fmt.Errorf(format, args...)}
+}
+
+// forbidden returns an error for an operation the user is not allowed to
+// perform, which maps to the forbidden exit code
+func forbidden(format string, args ...interface{}) error {
+	return &client.Error{Kind: client.ErrForbidden, Err: fmt.Errorf(format, args...)}
+}
+
+// cancelledError is returned when the user backs out at a prompt. It matches
+// prompt.ErrCancelled, which maps to the cancelled exit code.
+type cancelledError struct {
+	msg string
+}
+
+// Error implements the error interface
+func (e *cancelledError) Error() string {
+	return e.msg
+}
+
+// Unwrap allows errors.Is to match prompt.ErrCancelled
+func (e *cancelledError) Unwrap() error {
+	return prompt.ErrCancelled
+}
+
+// cancelled returns an error for an operation the user cancelled at a prompt
+func cancelled(format string, args ...interface{}) error {
+	return &cancelledError{msg: fmt.Sprintf(format, args...)}
+}
+
+// discussionsDisabled returns an error for a repository that has discussions
+// turned off, which maps to the discussions disabled exit code
+func discussionsDisabled(nameWithOwner string) error {
+	return &client.Error{
+		Kind: client.ErrDiscussionsDisabled,
+		Err:  fmt.Errorf("discussions are disabled for %s", nameWithOwner),
+	}
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/itchyny/gojq"
+	"github.com/spf13/cobra"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+)
+
+// addJSONFlags adds the --json and --jq flags to a command. Given without
+// fields, --json fails with the list of available fields instead of a usage
+// error.
+func addJSONFlags(cmd *cobra.Command, json, jq *string) {
+	cmd.Flags().StringVar(json, "json", "", "Output JSON with the specified fields")
+	cmd.Flags().StringVarP(jq, "jq", "q", "", "Filter JSON output using a jq expression")
+
+	cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		if c == cmd && err.Error() == "flag needs an argument: --json" {
+			return invalidInput("specify one or more comma-separated fields for --json:\n  %s",
+				strings.Join(jsonFieldNames(), "\n  "))
+		}
+		if parent := c.Parent(); parent != nil {
+			return parent.FlagErrorFunc()(c, err)
+		}
+		return err
+	})
+}
+
+// jsonFieldNames returns the discussion fields that can be passed to --json
+func jsonFieldNames() []string {
+	return client.DiscussionFieldNames()
+}
+
+// validateJSONFlags checks the values of the --json and --jq flags before
+// any requests are made
+func validateJSONFlags(json, jq string) error {
+	if jq == "" {
+		return validateJSONFields(parseJSONFields(json))
+	}
+
+	if json == "" {
+		return invalidInput("cannot use --jq without --json")
+	}
+	if _, err := gojq.Parse(jq); err != nil {
+		return invalidInput("invalid jq expression: %w", err)
+	}
+	return validateJSONFields(parseJSONFields(json))
+}
+
+// validateJSONFields returns an error listing the available fields if any of
+// the requested fields is unknown. Fields can be dotted paths such as
+// author.login.
+func validateJSONFields(fields []string) error {
+	available := jsonFieldNames()
+	for _, field := range fields {
+		name, _, _ := strings.Cut(field, ".")
+		if !slices.Contains(available, name) {
+			return invalidInput("unknown JSON field: %q\nAvailable fields:\n  %s",
+				name, strings.Join(available, "\n  "))
+		}
+		if err := client.ValidateFieldPath(field); err != nil {
+			return invalidInput("unknown JSON field: %q: %w", field, err)
+		}
+	}
+	return nil
+}
+
+// topLevelFields returns the discussion fields that need to be fetched for
+// the given field paths
+func topLevelFields(fields []string) []string {
+	var result []string
+	for _, field := range fields {
+		name, _, _ := strings.Cut(field, ".")
+		if !slices.Contains(result, name) {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// parseJSONFields parses the comma-separated JSON fields
+func parseJSONFields(fields string) []string {
+	if fields == "" {
+		return nil
+	}
+
+	var result []string
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field != "" {
+			result = append(result, field)
+		}
+	}
+	return result
+}
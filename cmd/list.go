@@ -1,8 +1,9 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"io"
 	"strings"
 
 	"github.com/cli/go-gh/v2/pkg/repository"
@@ -15,22 +16,27 @@ import (
 
 // listOptions holds the options for the list command
 type listOptions struct {
+	newClient  client.Factory
+	out        io.Writer
 	repo       string
 	author     string
 	search     string
 	category   string
 	answered   string
+	state      string
 	limit      int
+	all        bool
 	labels     []string
 	json       string
 	jsonFields []string
+	jq         string
 	template   string
 	web        bool
 }
 
 // NewListCmd creates the list command
-func NewListCmd() *cobra.Command {
-	opts := &listOptions{}
+func NewListCmd(f client.Factory) *cobra.Command {
+	opts := &listOptions{newClient: f}
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -39,7 +45,12 @@ func NewListCmd() *cobra.Command {
 
 The search query syntax is the same as GitHub's search syntax.
 For more information about the search syntax, see:
-https://docs.github.com/en/search-github/searching-on-github/searching-discussions`,
+https://docs.github.com/en/search-github/searching-on-github/searching-discussions
+
+Results are fetched in pages of up to 100 discussions until the limit is
+reached. Use '--limit 0' or '--all' to fetch every discussion. Note that
+GitHub's search API, used with '--search' and '--author', returns at most
+1,000 results.`,
 		Example: `  # List discussions in the current repository
   gh discussion list
 
@@ -59,19 +70,35 @@ https://docs.github.com/en/search-github/searching-on-github/searching-discussio
   gh discussion list --answered
   gh discussion list --unanswered
 
+  # Filter by state
+  gh discussion list --state closed
+
   # Limit the number of results
   gh discussion list -L 50
 
+  # Fetch every discussion in the repository
+  gh discussion list --all
+
   # Output as JSON with specific fields
   gh discussion list --json "number,title,author,category"
 
+  # Select nested fields with dotted paths
+  gh discussion list --json "number,author.login,comments.author.login"
+
+  # Filter JSON output with a jq expression
+  gh discussion list --json "number,title,isAnswered" -q '.[] | select(.isAnswered | not) | .title'
+
   # Use a custom template
   gh discussion list --template '{{range .}}{{.number}} {{.title}}{{"\n"}}{{end}}'
 
+  # Align output into columns with template helpers
+  gh discussion list --template '{{range .}}{{tablerow (printf "#%v" .number) .title (timeago .updatedAt)}}{{end}}'
+
   # Open in web browser
   gh discussion list -w`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runList(opts)
+			opts.out = cmd.OutOrStdout()
+			return runList(cmd.Context(), opts)
 		},
 	}
 
@@ -81,24 +108,33 @@ https://docs.github.com/en/search-github/searching-on-github/searching-discussio
 	// Filter options
 	cmd.Flags().StringVarP(&opts.author, "author", "a", "", "Filter by author")
 	cmd.Flags().StringVarP(&opts.search, "search", "S", "", "Search discussions with a query")
-	cmd.Flags().StringVar(&opts.category, "category", "", "Filter by category")
+	cmd.Flags().StringVar(&opts.category, "category", "", "Filter by category name or slug")
 	cmd.Flags().StringVar(&opts.answered, "answered", "", "Filter by answered status (true/false)")
+	cmd.Flags().StringVarP(&opts.state, "state", "s", "all", "Filter by state: {open|closed|all}")
 	cmd.Flags().StringSliceVarP(&opts.labels, "label", "l", nil, "Filter by labels")
 
 	// Output options
-	cmd.Flags().IntVarP(&opts.limit, "limit", "L", 30, "Maximum number of discussions to fetch")
-	cmd.Flags().StringVar(&opts.json, "json", "", "Output JSON with the specified fields")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "L", 30, "Maximum number of discussions to fetch (0 for no limit)")
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Fetch all discussions")
+	addJSONFlags(cmd, &opts.json, &opts.jq)
 	cmd.Flags().StringVar(&opts.template, "template", "", "Format JSON output using a Go template")
 	cmd.Flags().BoolVarP(&opts.web, "web", "w", false, "Open the discussion list in the web browser")
 
 	// Mark mutually exclusive flags
 	cmd.MarkFlagsMutuallyExclusive("json", "template", "web")
+	cmd.MarkFlagsMutuallyExclusive("jq", "template", "web")
+	cmd.MarkFlagsMutuallyExclusive("limit", "all")
 
 	return cmd
 }
 
 // runList executes the list command
-func runList(opts *listOptions) error {
+func runList(ctx context.Context, opts *listOptions) error {
+	// Validate JSON output options before making any requests
+	if err := validateJSONFlags(opts.json, opts.jq); err != nil {
+		return err
+	}
+
 	// Parse repository
 	repo, err := parseRepository(opts.repo)
 	if err != nil {
@@ -107,11 +143,11 @@ func runList(opts *listOptions) error {
 
 	// Handle web browser option
 	if opts.web {
-		return openInBrowser(fmt.Sprintf("https://github.com/%s/%s/discussions", repo.Owner, repo.Name))
+		return openInBrowser(opts.out, repo.URL()+"/discussions")
 	}
 
 	// Create GitHub client
-	client, err := client.NewGitHubClient()
+	client, err := opts.newClient(repo.Host)
 	if err != nil {
 		return fmt.Errorf("failed to create GitHub client: %w", err)
 	}
@@ -125,10 +161,27 @@ func runList(opts *listOptions) error {
 		case "false", "no", "0":
 			answered = &[]bool{false}[0]
 		default:
-			return fmt.Errorf("invalid value for --answered: %s (expected true/false)", opts.answered)
+			return invalidInput("invalid value for --answered: %s (expected true/false)", opts.answered)
 		}
 	}
 
+	// Validate limit
+	if opts.limit < 0 {
+		return invalidInput("invalid value for --limit: %d (expected 0 or greater)", opts.limit)
+	}
+	limit := opts.limit
+	if opts.all {
+		limit = 0
+	}
+
+	// Validate state filter
+	state := strings.ToLower(opts.state)
+	switch state {
+	case "open", "closed", "all":
+	default:
+		return invalidInput("invalid value for --state: %s (expected open/closed/all)", opts.state)
+	}
+
 	// Build list options
 	listOpts := models.ListOptions{
 		Owner:    repo.Owner,
@@ -137,12 +190,14 @@ func runList(opts *listOptions) error {
 		Search:   opts.search,
 		Category: opts.category,
 		Answered: answered,
-		Limit:    opts.limit,
+		State:    state,
+		Limit:    limit,
 		Labels:   opts.labels,
+		Fields:   topLevelFields(parseJSONFields(opts.json)),
 	}
 
 	// Fetch discussions
-	discussions, err := client.ListDiscussions(listOpts)
+	discussions, err := client.ListDiscussionsWithContext(ctx, listOpts)
 	if err != nil {
 		return fmt.Errorf("failed to list discussions: %w", err)
 	}
@@ -154,20 +209,21 @@ func runList(opts *listOptions) error {
 
 	if opts.json != "" {
 		outputOpts.Format = formatter.FormatJSON
-		if opts.json != "true" && opts.json != "1" {
-			outputOpts.Fields = parseJSONFields(opts.json)
-		}
+		outputOpts.Fields = parseJSONFields(opts.json)
+		outputOpts.JQFilter = opts.jq
 	} else if opts.template != "" {
 		outputOpts.Format = formatter.FormatTemplate
 		outputOpts.Template = opts.template
 	}
 
 	// Format and output results
-	f := formatter.NewFormatter(os.Stdout, outputOpts)
+	f := formatter.NewFormatter(opts.out, outputOpts)
 	return f.FormatDiscussionList(discussions.Nodes)
 }
 
-// parseRepository parses the repository string and returns owner/repo
+// parseRepository parses a repository in the [HOST/]OWNER/REPO format, or
+// returns the repository of the current directory when repoStr is empty.
+// Without a host, the default host from GH_HOST or the gh configuration is used.
 func parseRepository(repoStr string) (*Repository, error) {
 	if repoStr == "" {
 		// Try to get repository from current directory
@@ -176,48 +232,39 @@ func parseRepository(repoStr string) (*Repository, error) {
 			return nil, fmt.Errorf("unable to determine repository. Use -R flag to specify repository")
 		}
 		return &Repository{
+			Host:  currentRepo.Host,
 			Owner: currentRepo.Owner,
 			Name:  currentRepo.Name,
 		}, nil
 	}
 
-	// Parse owner/repo format
-	parts := strings.Split(repoStr, "/")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("invalid repository format. Expected OWNER/REPO")
+	repo, err := repository.Parse(repoStr)
+	if err != nil {
+		return nil, invalidInput("invalid repository format. Expected [HOST/]OWNER/REPO")
 	}
 
 	return &Repository{
-		Owner: parts[0],
-		Name:  parts[1],
+		Host:  repo.Host,
+		Owner: repo.Owner,
+		Name:  repo.Name,
 	}, nil
 }
 
 // Repository represents a GitHub repository
 type Repository struct {
+	Host  string
 	Owner string
 	Name  string
 }
 
-// parseJSONFields parses the comma-separated JSON fields
-func parseJSONFields(fields string) []string {
-	if fields == "" {
-		return nil
-	}
-
-	var result []string
-	for _, field := range strings.Split(fields, ",") {
-		field = strings.TrimSpace(field)
-		if field != "" {
-			result = append(result, field)
-		}
-	}
-	return result
+// URL returns the web URL of the repository on its host
+func (r *Repository) URL() string {
+	return fmt.Sprintf("https://%s/%s/%s", r.Host, r.Owner, r.Name)
 }
 
 // openInBrowser opens the specified URL in the default web browser
-func openInBrowser(url string) error {
-	fmt.Printf("Opening %s in your browser.\n", url)
+func openInBrowser(out io.Writer, url string) error {
+	fmt.Fprintf(out, "Opening %s in your browser.\n", url)
 	// This would typically use a library like browser.OpenURL
 	// For now, just print the URL
 	return nil
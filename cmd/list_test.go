@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+	"github.com/harakeishi/gh-discussion/pkg/client/clienttest"
+	"github.com/harakeishi/gh-discussion/pkg/models"
+)
+
+// newTestFake returns a fake with an octo/hello repository holding an open
+// Q&A discussion with a comment and a reply, and a closed general discussion
+func newTestFake(t *testing.T) (*clienttest.FakeClient, *clienttest.Repo) {
+	t.Helper()
+	t.Setenv("GH_HOST", "github.com")
+
+	fake := clienttest.New()
+	fake.Now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	repo := fake.AddRepository("octo", "hello")
+	qa := repo.AddCategory("Q&A", true)
+	general := repo.AddCategory("General", false)
+
+	repo.AddDiscussion(models.Discussion{
+		Title:     "How do I deploy?",
+		Body:      "Deployment question",
+		Author:    &models.User{Login: "alice"},
+		Category:  &qa,
+		UpdatedAt: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		Comments: &models.CommentConnection{
+			Nodes: []models.Comment{{
+				ID:         "DC_parent",
+				DatabaseID: 100,
+				Body:       "Use the deploy script",
+				Author:     &models.User{Login: "bob"},
+				Replies: &models.CommentConnection{
+					TotalCount: 1,
+					Nodes: []models.Comment{{
+						ID:         "DC_reply",
+						DatabaseID: 101,
+						Body:       "Thanks!",
+						Author:     &models.User{Login: "alice"},
+					}},
+				},
+			}},
+		},
+	})
+	repo.AddDiscussion(models.Discussion{
+		Title:     "Welcome",
+		Author:    &models.User{Login: "carol"},
+		Category:  &general,
+		Closed:    true,
+		UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	return fake, repo
+}
+
+func TestRunListJSON(t *testing.T) {
+	fake, _ := newTestFake(t)
+
+	var out bytes.Buffer
+	opts := &listOptions{
+		newClient: fake.Factory(),
+		out:       &out,
+		repo:      "octo/hello",
+		state:     "all",
+		limit:     30,
+		json:      "number,title,author.login",
+	}
+	if err := runList(context.Background(), opts); err != nil {
+		t.Fatalf("runList() error = %v", err)
+	}
+
+	var got []map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", out.String(), err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d discussions, want 2", len(got))
+	}
+	if got[0]["title"] != "How do I deploy?" || got[1]["title"] != "Welcome" {
+		t.Errorf("discussions are not ordered by update time: %v", got)
+	}
+	if _, ok := got[0]["body"]; ok {
+		t.Errorf("unrequested field body in output: %v", got[0])
+	}
+	author, _ := got[0]["author"].(map[string]interface{})
+	if author["login"] != "alice" || len(author) != 1 {
+		t.Errorf("author = %v, want only login alice", got[0]["author"])
+	}
+}
+
+func TestRunListFilters(t *testing.T) {
+	fake, _ := newTestFake(t)
+
+	tests := []struct {
+		name string
+		opts listOptions
+		want []float64
+	}{
+		{name: "open", opts: listOptions{state: "open"}, want: []float64{1}},
+		{name: "closed", opts: listOptions{state: "closed"}, want: []float64{2}},
+		{name: "category", opts: listOptions{state: "all", category: "general"}, want: []float64{2}},
+		{name: "author", opts: listOptions{state: "all", author: "alice"}, want: []float64{1}},
+		{name: "answered", opts: listOptions{state: "all", answered: "true"}, want: nil},
+		{name: "limit", opts: listOptions{state: "all", limit: 1}, want: []float64{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			opts := tt.opts
+			opts.newClient = fake.Factory()
+			opts.out = &out
+			opts.repo = "octo/hello"
+			opts.json = "number"
+
+			if err := runList(context.Background(), &opts); err != nil {
+				t.Fatalf("runList() error = %v", err)
+			}
+
+			var got []struct {
+				Number float64 `json:"number"`
+			}
+			if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+				t.Fatalf("invalid JSON output %q: %v", out.String(), err)
+			}
+			var numbers []float64
+			for _, d := range got {
+				numbers = append(numbers, d.Number)
+			}
+			if len(numbers) != len(tt.want) {
+				t.Fatalf("got discussions %v, want %v", numbers, tt.want)
+			}
+			for i := range numbers {
+				if numbers[i] != tt.want[i] {
+					t.Fatalf("got discussions %v, want %v", numbers, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestListCmdDefaults(t *testing.T) {
+	fake, _ := newTestFake(t)
+
+	var out bytes.Buffer
+	cmd := NewListCmd(fake.Factory())
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--repo", "octo/hello", "--json", "number"})
+	if err := cmd.ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("list error = %v", err)
+	}
+
+	// Without --state, both open and closed discussions are listed
+	if got := strings.Join(strings.Fields(out.String()), ""); got != `[{"number":1},{"number":2}]` {
+		t.Errorf("output = %s, want discussions 1 and 2", out.String())
+	}
+}
+
+func TestRunListTable(t *testing.T) {
+	fake, _ := newTestFake(t)
+
+	var out bytes.Buffer
+	opts := &listOptions{
+		newClient: fake.Factory(),
+		out:       &out,
+		repo:      "octo/hello",
+		state:     "open",
+		limit:     30,
+	}
+	if err := runList(context.Background(), opts); err != nil {
+		t.Fatalf("runList() error = %v", err)
+	}
+
+	for _, want := range []string{"NUMBER", "How do I deploy?", "alice", "Q&A"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("table output does not contain %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "Welcome") {
+		t.Errorf("table output contains a closed discussion:\n%s", out.String())
+	}
+}
+
+func TestRunListErrors(t *testing.T) {
+	fake, _ := newTestFake(t)
+	disabled := fake.AddRepository("octo", "quiet")
+	disabled.Info.HasDiscussionsEnabled = false
+
+	tests := []struct {
+		name string
+		opts listOptions
+		kind error
+	}{
+		{name: "invalid state", opts: listOptions{repo: "octo/hello", state: "merged"}, kind: client.ErrInvalidInput},
+		{name: "invalid answered", opts: listOptions{repo: "octo/hello", state: "open", answered: "maybe"}, kind: client.ErrInvalidInput},
+		{name: "unknown JSON field", opts: listOptions{repo: "octo/hello", state: "open", json: "nope"}, kind: client.ErrInvalidInput},
+		{name: "unknown category", opts: listOptions{repo: "octo/hello", state: "open", category: "Ideas"}, kind: client.ErrInvalidInput},
+		{name: "unknown repository", opts: listOptions{repo: "octo/missing", state: "open"}, kind: client.ErrNotFound},
+		{name: "discussions disabled", opts: listOptions{repo: "octo/quiet", state: "open"}, kind: client.ErrDiscussionsDisabled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			opts.newClient = fake.Factory()
+			opts.out = &bytes.Buffer{}
+
+			err := runList(context.Background(), &opts)
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("runList() error = %v, want kind %v", err, tt.kind)
+			}
+		})
+	}
+}
+
+func TestRunListWeb(t *testing.T) {
+	fake, _ := newTestFake(t)
+	fake.Errors["ListDiscussions"] = errors.New("should not be called")
+
+	var out bytes.Buffer
+	opts := &listOptions{newClient: fake.Factory(), out: &out, repo: "octo/hello", web: true}
+	if err := runList(context.Background(), opts); err != nil {
+		t.Fatalf("runList() error = %v", err)
+	}
+	if want := "https://github.com/octo/hello/discussions"; !strings.Contains(out.String(), want) {
+		t.Errorf("output = %q, want it to mention %s", out.String(), want)
+	}
+}
+
+func TestRunListEmpty(t *testing.T) {
+	fake, _ := newTestFake(t)
+
+	tests := []struct {
+		name string
+		opts listOptions
+		want string
+	}{
+		{name: "json", opts: listOptions{json: "number"}, want: "[]"},
+		{name: "jq", opts: listOptions{json: "number", jq: ".[].number"}, want: ""},
+		{name: "template", opts: listOptions{template: "{{len .}}"}, want: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			opts := tt.opts
+			opts.newClient = fake.Factory()
+			opts.out = &out
+			opts.repo = "octo/hello"
+			opts.state = "open"
+			opts.author = "nobody"
+
+			if err := runList(context.Background(), &opts); err != nil {
+				t.Fatalf("runList() error = %v", err)
+			}
+			if got := strings.TrimSpace(out.String()); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
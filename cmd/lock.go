@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+	"github.com/harakeishi/gh-discussion/pkg/models"
+)
+
+// lockOptions holds the options for the lock and unlock commands
+type lockOptions struct {
+	newClient client.Factory
+	out       io.Writer
+	repo      string
+	reason    string
+}
+
+// NewLockCmd creates the lock command
+func NewLockCmd(f client.Factory) *cobra.Command {
+	opts := &lockOptions{newClient: f}
+
+	cmd := &cobra.Command{
+		Use:   "lock {<number> | <url>}",
+		Short: "Lock discussion conversation",
+		Long: `Lock the conversation of a discussion so only collaborators can comment.
+
+The reason can be one of "off_topic", "too_heated", "resolved", or "spam".`,
+		Example: `  # Lock discussion #123
+  gh discussion lock 123
+
+  # Lock discussion #123 because it is too heated
+  gh discussion lock 123 --reason too_heated`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.out = cmd.OutOrStdout()
+			return runLock(cmd.Context(), opts, args[0])
+		},
+	}
+
+	// Repository options
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Select another repository using the [HOST/]OWNER/REPO format")
+
+	// Lock options
+	cmd.Flags().StringVarP(&opts.reason, "reason", "r", "", "Reason for locking: {off_topic|too_heated|resolved|spam}")
+
+	return cmd
+}
+
+// NewUnlockCmd creates the unlock command
+func NewUnlockCmd(f client.Factory) *cobra.Command {
+	opts := &lockOptions{newClient: f}
+
+	cmd := &cobra.Command{
+		Use:   "unlock {<number> | <url>}",
+		Short: "Unlock discussion conversation",
+		Example: `  # Unlock discussion #123
+  gh discussion unlock 123`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.out = cmd.OutOrStdout()
+			return runUnlock(cmd.Context(), opts, args[0])
+		},
+	}
+
+	// Repository options
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Select another repository using the [HOST/]OWNER/REPO format")
+
+	return cmd
+}
+
+// runLock executes the lock command
+func runLock(ctx context.Context, opts *lockOptions, discussionArg string) error {
+	// Validate lock reason
+	reason := strings.ToUpper(opts.reason)
+	switch reason {
+	case "", "OFF_TOPIC", "TOO_HEATED", "RESOLVED", "SPAM":
+	default:
+		return invalidInput("invalid value for --reason: %s (expected off_topic/too_heated/resolved/spam)", opts.reason)
+	}
+
+	// Parse discussion argument (number or URL)
+	repo, number, err := parseDiscussionArg(discussionArg, opts.repo)
+	if err != nil {
+		return fmt.Errorf("failed to parse discussion argument: %w", err)
+	}
+
+	// Create GitHub client
+	client, err := opts.newClient(repo.Host)
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	discussion, err := client.GetDiscussionWithContext(ctx, models.ViewOptions{
+		Owner:  repo.Owner,
+		Repo:   repo.Name,
+		Number: number,
+		Fields: []string{"number", "title", "locked"},
+	})
+	if err != nil {
+		return err
+	}
+
+	if discussion.Locked {
+		fmt.Fprintf(opts.out, "Discussion #%d (%s) is already locked\n", discussion.Number, discussion.Title)
+		return nil
+	}
+
+	if err := client.LockDiscussionWithContext(ctx, discussion.ID, reason); err != nil {
+		return err
+	}
+
+	if reason != "" {
+		fmt.Fprintf(opts.out, "Locked discussion #%d (%s) as %s\n", discussion.Number, discussion.Title, strings.ToLower(reason))
+	} else {
+		fmt.Fprintf(opts.out, "Locked discussion #%d (%s)\n", discussion.Number, discussion.Title)
+	}
+
+	return nil
+}
+
+// runUnlock executes the unlock command
+func runUnlock(ctx context.Context, opts *lockOptions, discussionArg string) error {
+	// Parse discussion argument (number or URL)
+	repo, number, err := parseDiscussionArg(discussionArg, opts.repo)
+	if err != nil {
+		return fmt.Errorf("failed to parse discussion argument: %w", err)
+	}
+
+	// Create GitHub client
+	client, err := opts.newClient(repo.Host)
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	discussion, err := client.GetDiscussionWithContext(ctx, models.ViewOptions{
+		Owner:  repo.Owner,
+		Repo:   repo.Name,
+		Number: number,
+		Fields: []string{"number", "title", "locked"},
+	})
+	if err != nil {
+		return err
+	}
+
+	if !discussion.Locked {
+		fmt.Fprintf(opts.out, "Discussion #%d (%s) is not locked\n", discussion.Number, discussion.Title)
+		return nil
+	}
+
+	if err := client.UnlockDiscussionWithContext(ctx, discussion.ID); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.out, "Unlocked discussion #%d (%s)\n", discussion.Number, discussion.Title)
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+)
+
+// rateLimitOptions holds the options for the rate-limit command
+type rateLimitOptions struct {
+	newClient client.Factory
+	out       io.Writer
+	hostname  string
+	json      bool
+}
+
+// NewRateLimitCmd creates the rate-limit command
+func NewRateLimitCmd(f client.Factory) *cobra.Command {
+	opts := &rateLimitOptions{newClient: f}
+
+	cmd := &cobra.Command{
+		Use:   "rate-limit",
+		Short: "Show the GitHub GraphQL API rate limit status",
+		Long: `Show how much of the GitHub GraphQL API rate limit is left and when it resets.
+
+All commands retry automatically with backoff when GitHub rate limits a request.`,
+		Example: `  # Show the rate limit status
+  gh discussion rate-limit
+
+  # Show the rate limit status on a GitHub Enterprise Server host
+  gh discussion rate-limit --hostname github.example.com
+
+  # Show the rate limit status as JSON
+  gh discussion rate-limit --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.out = cmd.OutOrStdout()
+			return runRateLimit(cmd.Context(), opts)
+		},
+	}
+
+	// Host options
+	cmd.Flags().StringVar(&opts.hostname, "hostname", "", "The GitHub host to check (default: GH_HOST or the gh default host)")
+
+	// Output options
+	cmd.Flags().BoolVar(&opts.json, "json", false, "Output JSON")
+
+	return cmd
+}
+
+// runRateLimit executes the rate-limit command
+func runRateLimit(ctx context.Context, opts *rateLimitOptions) error {
+	// Create GitHub client
+	client, err := opts.newClient(opts.hostname)
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	rateLimit, err := client.GetRateLimitWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if opts.json {
+		encoder := json.NewEncoder(opts.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rateLimit)
+	}
+
+	resetIn := time.Until(rateLimit.ResetAt).Round(time.Second)
+	if resetIn < 0 {
+		resetIn = 0
+	}
+
+	fmt.Fprintf(opts.out, "Limit:     %d\n", rateLimit.Limit)
+	fmt.Fprintf(opts.out, "Used:      %d\n", rateLimit.Used)
+	fmt.Fprintf(opts.out, "Remaining: %d\n", rateLimit.Remaining)
+	fmt.Fprintf(opts.out, "Resets:    %s (in %s)\n", rateLimit.ResetAt.Local().Format("2006-01-02 15:04:05"), resetIn)
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+	"github.com/harakeishi/gh-discussion/pkg/models"
+)
+
+// reopenOptions holds the options for the reopen command
+type reopenOptions struct {
+	newClient client.Factory
+	out       io.Writer
+	repo      string
+}
+
+// NewReopenCmd creates the reopen command
+func NewReopenCmd(f client.Factory) *cobra.Command {
+	opts := &reopenOptions{newClient: f}
+
+	cmd := &cobra.Command{
+		Use:   "reopen {<number> | <url>}",
+		Short: "Reopen a discussion",
+		Example: `  # Reopen discussion #123
+  gh discussion reopen 123`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.out = cmd.OutOrStdout()
+			return runReopen(cmd.Context(), opts, args[0])
+		},
+	}
+
+	// Repository options
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Select another repository using the [HOST/]OWNER/REPO format")
+
+	return cmd
+}
+
+// runReopen executes the reopen command
+func runReopen(ctx context.Context, opts *reopenOptions, discussionArg string) error {
+	// Parse discussion argument (number or URL)
+	repo, number, err := parseDiscussionArg(discussionArg, opts.repo)
+	if err != nil {
+		return fmt.Errorf("failed to parse discussion argument: %w", err)
+	}
+
+	// Create GitHub client
+	client, err := opts.newClient(repo.Host)
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	discussion, err := client.GetDiscussionWithContext(ctx, models.ViewOptions{
+		Owner:  repo.Owner,
+		Repo:   repo.Name,
+		Number: number,
+		Fields: []string{"number", "title", "closed"},
+	})
+	if err != nil {
+		return err
+	}
+
+	if !discussion.Closed {
+		fmt.Fprintf(opts.out, "Discussion #%d (%s) is already open\n", discussion.Number, discussion.Title)
+		return nil
+	}
+
+	if _, err := client.ReopenDiscussionWithContext(ctx, discussion.ID); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.out, "Reopened discussion #%d (%s)\n", discussion.Number, discussion.Title)
+
+	return nil
+}
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"io"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -15,16 +17,19 @@ import (
 
 // viewOptions holds the options for the view command
 type viewOptions struct {
-	repo     string
-	comments bool
-	json     string
-	template string
-	web      bool
+	newClient client.Factory
+	out       io.Writer
+	repo      string
+	comments  bool
+	json      string
+	jq        string
+	template  string
+	web       bool
 }
 
 // NewViewCmd creates the view command
-func NewViewCmd() *cobra.Command {
-	opts := &viewOptions{}
+func NewViewCmd(f client.Factory) *cobra.Command {
+	opts := &viewOptions{newClient: f}
 
 	cmd := &cobra.Command{
 		Use:   "view {<number> | <url>}",
@@ -45,12 +50,15 @@ With '--web', open the discussion in a web browser instead.`,
   # View discussion with comments
   gh discussion view 123 -c
 
-  # View discussion as JSON
+  # List the fields available for JSON output
   gh discussion view 123 --json
 
   # View specific fields as JSON
   gh discussion view 123 --json "title,body,author,comments"
 
+  # Extract a single value with a jq expression
+  gh discussion view 123 --json author -q .author.login
+
   # Use a custom template
   gh discussion view 123 --template '{{.title}} by {{.author.login}}'
 
@@ -58,7 +66,8 @@ With '--web', open the discussion in a web browser instead.`,
   gh discussion view 123 -w`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runView(opts, args[0])
+			opts.out = cmd.OutOrStdout()
+			return runView(cmd.Context(), opts, args[0])
 		},
 	}
 
@@ -69,18 +78,24 @@ With '--web', open the discussion in a web browser instead.`,
 	cmd.Flags().BoolVarP(&opts.comments, "comments", "c", false, "View discussion comments")
 
 	// Output options
-	cmd.Flags().StringVar(&opts.json, "json", "", "Output JSON with the specified fields")
+	addJSONFlags(cmd, &opts.json, &opts.jq)
 	cmd.Flags().StringVar(&opts.template, "template", "", "Format JSON output using a Go template")
 	cmd.Flags().BoolVarP(&opts.web, "web", "w", false, "Open the discussion in the web browser")
 
 	// Mark mutually exclusive flags
 	cmd.MarkFlagsMutuallyExclusive("json", "template", "web")
+	cmd.MarkFlagsMutuallyExclusive("jq", "template", "web")
 
 	return cmd
 }
 
 // runView executes the view command
-func runView(opts *viewOptions, discussionArg string) error {
+func runView(ctx context.Context, opts *viewOptions, discussionArg string) error {
+	// Validate JSON output options before making any requests
+	if err := validateJSONFlags(opts.json, opts.jq); err != nil {
+		return err
+	}
+
 	// Parse discussion argument (number or URL)
 	repo, number, err := parseDiscussionArg(discussionArg, opts.repo)
 	if err != nil {
@@ -89,11 +104,11 @@ func runView(opts *viewOptions, discussionArg string) error {
 
 	// Handle web browser option
 	if opts.web {
-		return openInBrowser(fmt.Sprintf("https://github.com/%s/%s/discussions/%d", repo.Owner, repo.Name, number))
+		return openInBrowser(opts.out, fmt.Sprintf("%s/discussions/%d", repo.URL(), number))
 	}
 
 	// Create GitHub client
-	client, err := client.NewGitHubClient()
+	client, err := opts.newClient(repo.Host)
 	if err != nil {
 		return fmt.Errorf("failed to create GitHub client: %w", err)
 	}
@@ -104,10 +119,11 @@ func runView(opts *viewOptions, discussionArg string) error {
 		Repo:         repo.Name,
 		Number:       number,
 		ShowComments: opts.comments,
+		Fields:       topLevelFields(parseJSONFields(opts.json)),
 	}
 
 	// Fetch discussion
-	discussion, err := client.GetDiscussion(viewOpts)
+	discussion, err := client.GetDiscussionWithContext(ctx, viewOpts)
 	if err != nil {
 		return fmt.Errorf("failed to get discussion: %w", err)
 	}
@@ -119,30 +135,29 @@ func runView(opts *viewOptions, discussionArg string) error {
 
 	if opts.json != "" {
 		outputOpts.Format = formatter.FormatJSON
-		if opts.json != "true" && opts.json != "1" {
-			outputOpts.Fields = parseJSONFields(opts.json)
-		}
+		outputOpts.Fields = parseJSONFields(opts.json)
+		outputOpts.JQFilter = opts.jq
 	} else if opts.template != "" {
 		outputOpts.Format = formatter.FormatTemplate
 		outputOpts.Template = opts.template
 	}
 
 	// Format and output result
-	f := formatter.NewFormatter(os.Stdout, outputOpts)
+	f := formatter.NewFormatter(opts.out, outputOpts)
 	return f.FormatDiscussion(discussion)
 }
 
 // parseDiscussionArg parses the discussion argument which can be a number or URL
 func parseDiscussionArg(arg, repoStr string) (*Repository, int, error) {
 	// Check if it's a URL
-	if strings.HasPrefix(arg, "https://github.com/") {
+	if isURL(arg) {
 		return parseDiscussionURL(arg)
 	}
 
 	// Parse as discussion number
 	number, err := strconv.Atoi(arg)
 	if err != nil {
-		return nil, 0, fmt.Errorf("invalid discussion number: %s", arg)
+		return nil, 0, invalidInput("invalid discussion number: %s", arg)
 	}
 
 	// Parse repository
@@ -154,14 +169,18 @@ func parseDiscussionArg(arg, repoStr string) (*Repository, int, error) {
 	return repo, number, nil
 }
 
-// parseDiscussionURL parses a GitHub discussion URL
-func parseDiscussionURL(url string) (*Repository, int, error) {
-	// Expected format: https://github.com/owner/repo/discussions/123
-	url = strings.TrimPrefix(url, "https://github.com/")
-	parts := strings.Split(url, "/")
+// parseDiscussionURL parses a discussion URL on github.com or a GitHub
+// Enterprise Server host
+func parseDiscussionURL(rawURL string) (*Repository, int, error) {
+	// Expected format: https://HOST/owner/repo/discussions/123
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil, 0, invalidInput("invalid discussion URL format")
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
 
 	if len(parts) != 4 || parts[2] != "discussions" {
-		return nil, 0, fmt.Errorf("invalid discussion URL format")
+		return nil, 0, invalidInput("invalid discussion URL format")
 	}
 
 	owner := parts[0]
@@ -169,11 +188,41 @@ func parseDiscussionURL(url string) (*Repository, int, error) {
 
 	number, err := strconv.Atoi(parts[3])
 	if err != nil {
-		return nil, 0, fmt.Errorf("invalid discussion number in URL: %s", parts[3])
+		return nil, 0, invalidInput("invalid discussion number in URL: %s", parts[3])
 	}
 
 	return &Repository{
+		Host:  u.Host,
 		Owner: owner,
 		Name:  repo,
 	}, number, nil
 }
+
+// isURL reports whether a discussion or comment argument is a web URL rather
+// than a number or node ID
+func isURL(arg string) bool {
+	return strings.HasPrefix(arg, "https://") || strings.HasPrefix(arg, "http://")
+}
+
+// parseCommentURL parses a GitHub discussion comment URL and returns the
+// discussion and the comment's database ID
+func parseCommentURL(rawURL string) (*Repository, int, int, error) {
+	// Expected format: https://HOST/owner/repo/discussions/123#discussioncomment-456
+	_, fragment, _ := strings.Cut(rawURL, "#")
+	idStr, ok := strings.CutPrefix(fragment, "discussioncomment-")
+	if !ok {
+		return nil, 0, 0, invalidInput("invalid comment URL format")
+	}
+
+	commentID, err := strconv.Atoi(idStr)
+	if err != nil {
+		return nil, 0, 0, invalidInput("invalid comment ID in URL: %s", idStr)
+	}
+
+	repo, number, err := parseDiscussionURL(rawURL)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	return repo, number, commentID, nil
+}
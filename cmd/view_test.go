@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+)
+
+func TestRunViewJSON(t *testing.T) {
+	fake, _ := newTestFake(t)
+
+	var out bytes.Buffer
+	opts := &viewOptions{
+		newClient: fake.Factory(),
+		out:       &out,
+		repo:      "octo/hello",
+		json:      "title,category.name,comments.author.login",
+	}
+	if err := runView(context.Background(), opts, "1"); err != nil {
+		t.Fatalf("runView() error = %v", err)
+	}
+
+	var got struct {
+		Title    string                 `json:"title"`
+		Body     *string                `json:"body"`
+		Category map[string]interface{} `json:"category"`
+		Comments struct {
+			Nodes []map[string]interface{} `json:"nodes"`
+		} `json:"comments"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", out.String(), err)
+	}
+	if got.Title != "How do I deploy?" {
+		t.Errorf("title = %q, want %q", got.Title, "How do I deploy?")
+	}
+	if got.Body != nil {
+		t.Errorf("unrequested field body in output: %q", out.String())
+	}
+	if got.Category["name"] != "Q&A" || len(got.Category) != 1 {
+		t.Errorf("category = %v, want only name Q&A", got.Category)
+	}
+	if len(got.Comments.Nodes) != 1 {
+		t.Fatalf("got %d comments, want 1", len(got.Comments.Nodes))
+	}
+	comment := got.Comments.Nodes[0]
+	if author, _ := comment["author"].(map[string]interface{}); author["login"] != "bob" || len(comment) != 1 {
+		t.Errorf("comment = %v, want only author.login bob", comment)
+	}
+}
+
+func TestRunViewJQ(t *testing.T) {
+	fake, _ := newTestFake(t)
+
+	var out bytes.Buffer
+	opts := &viewOptions{
+		newClient: fake.Factory(),
+		out:       &out,
+		repo:      "octo/hello",
+		json:      "number,title",
+		jq:        ".title",
+	}
+	if err := runView(context.Background(), opts, "https://github.com/octo/hello/discussions/1"); err != nil {
+		t.Fatalf("runView() error = %v", err)
+	}
+	if got := strings.TrimSpace(out.String()); got != "How do I deploy?" {
+		t.Errorf("output = %q, want %q", got, "How do I deploy?")
+	}
+}
+
+func TestRunViewTable(t *testing.T) {
+	fake, _ := newTestFake(t)
+
+	var out bytes.Buffer
+	opts := &viewOptions{
+		newClient: fake.Factory(),
+		out:       &out,
+		repo:      "octo/hello",
+		comments:  true,
+	}
+	if err := runView(context.Background(), opts, "1"); err != nil {
+		t.Fatalf("runView() error = %v", err)
+	}
+
+	for _, want := range []string{"How do I deploy?", "Deployment question", "Use the deploy script", "Thanks!"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRunViewErrors(t *testing.T) {
+	fake, _ := newTestFake(t)
+
+	tests := []struct {
+		name string
+		arg  string
+		opts viewOptions
+		kind error
+	}{
+		{name: "missing discussion", arg: "42", opts: viewOptions{repo: "octo/hello"}, kind: client.ErrNotFound},
+		{name: "invalid number", arg: "abc", opts: viewOptions{repo: "octo/hello"}, kind: client.ErrInvalidInput},
+		{name: "invalid nested field", arg: "1", opts: viewOptions{repo: "octo/hello", json: "author.id"}, kind: client.ErrInvalidInput},
+		{name: "jq without json", arg: "1", opts: viewOptions{repo: "octo/hello", jq: ".title"}, kind: client.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			opts.newClient = fake.Factory()
+			opts.out = &bytes.Buffer{}
+
+			err := runView(context.Background(), &opts, tt.arg)
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("runView() error = %v, want kind %v", err, tt.kind)
+			}
+		})
+	}
+}
@@ -1,22 +1,57 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/harakeishi/gh-discussion/cmd"
+	"github.com/harakeishi/gh-discussion/pkg/client"
+	"github.com/harakeishi/gh-discussion/pkg/prompt"
+)
+
+// Exit codes. Scripts can rely on these to tell failures apart.
+const (
+	exitOK                  = 0 // The command succeeded
+	exitError               = 1 // Any error not covered below
+	exitCancel              = 2 // The command was cancelled with Ctrl-C or at a prompt
+	exitInvalidInput        = 3 // Invalid flags, arguments, or input rejected by GitHub
+	exitForbidden           = 4 // Authentication is missing or the operation is not permitted
+	exitNotFound            = 5 // The repository, discussion, or comment does not exist
+	exitRateLimited         = 6 // The GitHub API rate limit was exceeded
+	exitDiscussionsDisabled = 7 // Discussions are not enabled for the repository
 )
 
 func main() {
+	var timeout time.Duration
+
+	// Create clients when a command runs, after --timeout has been parsed
+	newClient := func(host string) (client.DiscussionAPI, error) {
+		return client.NewFactory(client.Options{Timeout: timeout, Notices: os.Stderr})(host)
+	}
+
 	rootCmd := &cobra.Command{
 		Use:   "gh-discussion",
 		Short: "GitHub CLI extension for managing discussions",
 		Long: `A GitHub CLI extension for managing discussions.
 
-This extension provides commands to list, view, and create discussions
-in GitHub repositories, similar to how gh issue and gh pr work.`,
+This extension provides commands to list, view, create, edit, and comment on discussions
+in GitHub repositories, similar to how gh issue and gh pr work.
+
+Exit codes:
+  0  success
+  1  any other error
+  2  cancelled
+  3  invalid input
+  4  authentication missing or permission denied
+  5  repository, discussion, or comment not found
+  6  API rate limit exceeded
+  7  discussions are not enabled for the repository`,
 		Example: `  # List discussions in the current repository
   gh discussion list
 
@@ -29,14 +64,78 @@ in GitHub repositories, similar to how gh issue and gh pr work.`,
 		SilenceErrors: true,
 	}
 
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &client.Error{Kind: client.ErrInvalidInput, Err: err}
+	})
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "Abort API requests that take longer than this duration (e.g. 30s, 2m)")
+
 	// Add subcommands
-	rootCmd.AddCommand(cmd.NewListCmd())
-	rootCmd.AddCommand(cmd.NewViewCmd())
-	rootCmd.AddCommand(cmd.NewCreateCmd())
+	rootCmd.AddCommand(cmd.NewListCmd(newClient))
+	rootCmd.AddCommand(cmd.NewViewCmd(newClient))
+	rootCmd.AddCommand(cmd.NewCreateCmd(newClient))
+	rootCmd.AddCommand(cmd.NewCommentCmd(newClient))
+	rootCmd.AddCommand(cmd.NewEditCmd(newClient))
+	rootCmd.AddCommand(cmd.NewCloseCmd(newClient))
+	rootCmd.AddCommand(cmd.NewReopenCmd(newClient))
+	rootCmd.AddCommand(cmd.NewLockCmd(newClient))
+	rootCmd.AddCommand(cmd.NewUnlockCmd(newClient))
+	rootCmd.AddCommand(cmd.NewAnswerCmd(newClient))
+	rootCmd.AddCommand(cmd.NewDeleteCmd(newClient))
+	rootCmd.AddCommand(cmd.NewEnableCmd(newClient))
+	rootCmd.AddCommand(cmd.NewDisableCmd(newClient))
+	rootCmd.AddCommand(cmd.NewRateLimitCmd(newClient))
+
+	// Cancel in-flight requests on Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
 
 	// Execute the command
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	if err != nil {
+		if timeout > 0 && errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("request timed out after %s: %w", timeout, err)
+		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		if hint := errorHint(err); hint != "" {
+			fmt.Fprintln(os.Stderr, hint)
+		}
+		os.Exit(exitCode(err))
+	}
+	os.Exit(exitOK)
+}
+
+// exitCode maps an error to the documented exit code for its kind
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, prompt.ErrCancelled), errors.Is(err, context.Canceled):
+		return exitCancel
+	case errors.Is(err, client.ErrInvalidInput):
+		return exitInvalidInput
+	case errors.Is(err, client.ErrForbidden):
+		return exitForbidden
+	case errors.Is(err, client.ErrNotFound):
+		return exitNotFound
+	case errors.Is(err, client.ErrRateLimited):
+		return exitRateLimited
+	case errors.Is(err, client.ErrDiscussionsDisabled):
+		return exitDiscussionsDisabled
+	default:
+		return exitError
+	}
+}
+
+// errorHint returns a suggestion for resolving an error, if there is one
+func errorHint(err error) string {
+	switch {
+	case errors.Is(err, client.ErrForbidden):
+		return "Check that you are logged in with 'gh auth status' and have access to the repository."
+	case errors.Is(err, client.ErrNotFound):
+		return "Check the repository and number, and that you have access to the repository."
+	case errors.Is(err, client.ErrRateLimited):
+		return "Check when the rate limit resets with 'gh discussion rate-limit'."
+	case errors.Is(err, client.ErrDiscussionsDisabled):
+		return "Run 'gh discussion enable' to turn on discussions (requires admin access to the repository)."
+	default:
+		return ""
 	}
 }
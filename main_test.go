@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+	"github.com/harakeishi/gh-discussion/pkg/prompt"
+)
+
+func TestExitCode(t *testing.T) {
+	kind := func(kind error) error {
+		return fmt.Errorf("1: %w", &client.Error{Kind: kind, Err: errors.New("failed")})
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "prompt cancelled", err: fmt.Errorf("create: %w", prompt.ErrCancelled), want: exitCancel},
+		{name: "context cancelled", err: fmt.Errorf("request: %w", context.Canceled), want: exitCancel},
+		{name: "invalid input", err: kind(client.ErrInvalidInput), want: exitInvalidInput},
+		{name: "forbidden", err: kind(client.ErrForbidden), want: exitForbidden},
+		{name: "not found", err: kind(client.ErrNotFound), want: exitNotFound},
+		{name: "rate limited", err: kind(client.ErrRateLimited), want: exitRateLimited},
+		{name: "discussions disabled", err: kind(client.ErrDiscussionsDisabled), want: exitDiscussionsDisabled},
+		{name: "timeout", err: context.DeadlineExceeded, want: exitError},
+		{name: "other", err: errors.New("boom"), want: exitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorHint(t *testing.T) {
+	for _, kind := range []error{client.ErrForbidden, client.ErrNotFound, client.ErrRateLimited, client.ErrDiscussionsDisabled} {
+		if errorHint(&client.Error{Kind: kind, Err: errors.New("failed")}) == "" {
+			t.Errorf("errorHint() has no hint for %v", kind)
+		}
+	}
+	if hint := errorHint(errors.New("boom")); hint != "" {
+		t.Errorf("errorHint() = %q for an unclassified error, want none", hint)
+	}
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+
+	"github.com/harakeishi/gh-discussion/pkg/models"
+)
+
+// DiscussionAPI is the set of GitHub Discussions operations used by the
+// commands. GitHubClient implements it against the GitHub GraphQL API, and
+// clienttest.FakeClient implements it in memory for offline tests. Every
+// operation takes a context so that callers can cancel it or set deadlines.
+type DiscussionAPI interface {
+	// Queries
+	ListDiscussionsWithContext(ctx context.Context, opts models.ListOptions) (*models.DiscussionConnection, error)
+	GetDiscussionWithContext(ctx context.Context, opts models.ViewOptions) (*models.Discussion, error)
+	GetDiscussionIDWithContext(ctx context.Context, owner, repo string, number int) (string, error)
+	GetDiscussionCategoriesWithContext(ctx context.Context, owner, repo string) ([]models.Category, error)
+	GetDiscussionTemplateWithContext(ctx context.Context, owner, repo, categorySlug string) ([]byte, error)
+	GetRepositoryInfoWithContext(ctx context.Context, owner, repo string) (*models.Repository, error)
+	GetLabelIDsWithContext(ctx context.Context, owner, repo string, names []string) ([]string, error)
+	GetDiscussionCommentWithContext(ctx context.Context, commentID string) (*models.Comment, error)
+	FindDiscussionCommentWithContext(ctx context.Context, owner, repo string, number, databaseID int) (*models.Comment, error)
+	GetRateLimitWithContext(ctx context.Context) (*models.RateLimit, error)
+
+	// Mutations
+	CreateDiscussionWithContext(ctx context.Context, opts models.CreateOptions) (*models.Discussion, error)
+	UpdateDiscussionWithContext(ctx context.Context, opts models.UpdateOptions) (*models.Discussion, error)
+	CloseDiscussionWithContext(ctx context.Context, discussionID, reason string) (*models.Discussion, error)
+	ReopenDiscussionWithContext(ctx context.Context, discussionID string) (*models.Discussion, error)
+	LockDiscussionWithContext(ctx context.Context, discussionID, reason string) error
+	UnlockDiscussionWithContext(ctx context.Context, discussionID string) error
+	DeleteDiscussionWithContext(ctx context.Context, discussionID string) error
+	AddLabelsWithContext(ctx context.Context, labelableID string, labelIDs []string) error
+	RemoveLabelsWithContext(ctx context.Context, labelableID string, labelIDs []string) error
+	AddDiscussionCommentWithContext(ctx context.Context, opts models.CommentOptions) (*models.Comment, error)
+	DeleteDiscussionCommentWithContext(ctx context.Context, commentID string) error
+	SetDiscussionsEnabledWithContext(ctx context.Context, owner, repo string, enabled bool) error
+	MarkCommentAsAnswerWithContext(ctx context.Context, commentID string) error
+	UnmarkCommentAsAnswerWithContext(ctx context.Context, commentID string) error
+}
+
+// Factory creates the DiscussionAPI used by a command to talk to a GitHub
+// host. An empty host selects the default host.
+type Factory func(host string) (DiscussionAPI, error)
+
+// DefaultFactory creates a GitHubClient for the host using the GitHub CLI's authentication
+func DefaultFactory(host string) (DiscussionAPI, error) {
+	return NewFactory(Options{})(host)
+}
+
+// NewFactory returns a Factory that creates GitHubClients configured by opts.
+// The host passed to the factory replaces opts.Host.
+func NewFactory(opts Options) Factory {
+	return func(host string) (DiscussionAPI, error) {
+		hostOpts := opts
+		hostOpts.Host = host
+		c, err := NewGitHubClientWithOptions(hostOpts)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+}
+
+var _ DiscussionAPI = (*GitHubClient)(nil)
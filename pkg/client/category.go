@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harakeishi/gh-discussion/pkg/models"
+)
+
+// FindCategory looks up a discussion category by name or slug. Exact matches
+// win over case-insensitive ones. When nothing matches, the returned
+// ErrInvalidInput error lists the available categories and suggests the
+// closest one.
+func FindCategory(categories []models.Category, name string) (*models.Category, error) {
+	for _, equal := range []func(a, b string) bool{
+		func(a, b string) bool { return a == b },
+		strings.EqualFold,
+	} {
+		for i := range categories {
+			if equal(categories[i].Name, name) || equal(categories[i].Slug, name) {
+				return &categories[i], nil
+			}
+		}
+	}
+
+	names := make([]string, len(categories))
+	for i, category := range categories {
+		names[i] = category.Name
+	}
+
+	if len(names) == 0 {
+		return nil, newError(ErrInvalidInput, "category %q not found. The repository has no discussion categories", name)
+	}
+	if suggestion := closestCategory(categories, name); suggestion != "" {
+		return nil, newError(ErrInvalidInput, "category %q not found. Did you mean %q? Available categories: %s", name, suggestion, strings.Join(names, ", "))
+	}
+	return nil, newError(ErrInvalidInput, "category %q not found. Available categories: %s", name, strings.Join(names, ", "))
+}
+
+// closestCategory returns the name of the category whose name or slug is
+// closest to name by edit distance, or an empty string if none is close
+// enough to be a likely typo
+func closestCategory(categories []models.Category, name string) string {
+	name = strings.ToLower(name)
+
+	best, bestDistance := "", -1
+	for _, category := range categories {
+		for _, candidate := range []string{category.Name, category.Slug} {
+			if candidate == "" {
+				continue
+			}
+			d := editDistance(name, strings.ToLower(candidate))
+			if bestDistance < 0 || d < bestDistance {
+				best, bestDistance = category.Name, d
+			}
+		}
+	}
+
+	// Allow roughly one typo for every three characters
+	if bestDistance < 0 || bestDistance > len([]rune(name))/3+1 {
+		return ""
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+
+	prev := make([]int, len(t)+1)
+	curr := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(t)]
+}
+
+// getCategory looks up a discussion category of a repository by name or slug
+func (c *GitHubClient) getCategory(ctx context.Context, owner, repo, name string) (*models.Category, error) {
+	categories, err := c.GetDiscussionCategoriesWithContext(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return FindCategory(categories, name)
+}
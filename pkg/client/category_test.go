@@ -0,0 +1,120 @@
+package client
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harakeishi/gh-discussion/pkg/models"
+)
+
+var testCategories = []models.Category{
+	{ID: "general", Name: "General", Slug: "general"},
+	{ID: "qa", Name: "Q&A", Slug: "q-a"},
+	// The case-insensitive match comes first, so exact matches must be preferred
+	{ID: "shout", Name: "SHOW-AND-TELL", Slug: "shouting"},
+	{ID: "show", Name: "Show and tell", Slug: "show-and-tell"},
+}
+
+func TestFindCategory(t *testing.T) {
+	tests := []struct {
+		name   string
+		lookup string
+		want   string
+	}{
+		{name: "exact name", lookup: "Q&A", want: "qa"},
+		{name: "exact slug", lookup: "q-a", want: "qa"},
+		{name: "case-insensitive name", lookup: "general", want: "general"},
+		{name: "case-insensitive slug", lookup: "Q-A", want: "qa"},
+		{name: "exact slug beats case-insensitive name", lookup: "show-and-tell", want: "show"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindCategory(testCategories, tt.lookup)
+			if err != nil {
+				t.Fatalf("FindCategory(%q) error = %v", tt.lookup, err)
+			}
+			if got.ID != tt.want {
+				t.Errorf("FindCategory(%q) = %s, want %s", tt.lookup, got.ID, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindCategoryNotFound(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []models.Category
+		lookup     string
+		want       string
+		suggests   bool
+	}{
+		{name: "typo", categories: testCategories, lookup: "Genral", want: `Did you mean "General"? Available categories: General, Q&A, SHOW-AND-TELL, Show and tell`, suggests: true},
+		{name: "no close match", categories: testCategories, lookup: "Announcements", want: "Available categories: General, Q&A, SHOW-AND-TELL, Show and tell"},
+		{name: "no categories", lookup: "General", want: "The repository has no discussion categories"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FindCategory(tt.categories, tt.lookup)
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Fatalf("FindCategory(%q) error = %v, want ErrInvalidInput", tt.lookup, err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("FindCategory(%q) error = %v, want %q", tt.lookup, err, tt.want)
+			}
+			if got := strings.Contains(err.Error(), "Did you mean"); got != tt.suggests {
+				t.Errorf("FindCategory(%q) suggests a category = %v, want %v", tt.lookup, got, tt.suggests)
+			}
+		})
+	}
+}
+
+func TestClosestCategory(t *testing.T) {
+	tests := []struct {
+		lookup string
+		want   string
+	}{
+		{lookup: "Genral", want: "General"},
+		{lookup: "GENERL", want: "General"},
+		{lookup: "q-b", want: "Q&A"},
+		{lookup: "show and tel", want: "Show and tell"},
+		{lookup: "shoutin", want: "SHOW-AND-TELL"},
+		{lookup: "Announcements", want: ""},
+		{lookup: "zzz", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lookup, func(t *testing.T) {
+			if got := closestCategory(testCategories, tt.lookup); got != tt.want {
+				t.Errorf("closestCategory(%q) = %q, want %q", tt.lookup, got, tt.want)
+			}
+		})
+	}
+
+	if got := closestCategory(nil, "General"); got != "" {
+		t.Errorf("closestCategory() without categories = %q, want none", got)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "", b: "abc", want: 3},
+		{a: "abc", b: "", want: 3},
+		{a: "general", b: "general", want: 0},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "flaw", b: "lawn", want: 2},
+		{a: "café", b: "cafe", want: 1},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
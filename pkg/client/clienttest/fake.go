@@ -0,0 +1,1085 @@
+// Package clienttest provides an in-memory implementation of
+// client.DiscussionAPI for tests that must not touch the network.
+package clienttest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+	"github.com/harakeishi/gh-discussion/pkg/models"
+)
+
+// FakeClient is an in-memory client.DiscussionAPI. Populate it with
+// AddRepository and the Repo helpers; mutations update the stored data so
+// later queries observe them.
+type FakeClient struct {
+	// Errors makes the named operation (e.g. "GetDiscussion" for
+	// GetDiscussionWithContext) fail with the given error
+	Errors map[string]error
+	// Now returns the time used for created, updated, and closed timestamps
+	Now func() time.Time
+	// RateLimit is returned by GetRateLimit; a full budget is reported when nil
+	RateLimit *models.RateLimit
+
+	mu     sync.Mutex
+	repos  []*Repo
+	nextID int
+}
+
+// Repo holds the in-memory state of a single repository
+type Repo struct {
+	Info        models.Repository
+	Categories  []models.Category
+	Labels      []models.Label
+	Templates   map[string]string
+	Discussions []*models.Discussion
+
+	fake *FakeClient
+}
+
+var _ client.DiscussionAPI = (*FakeClient)(nil)
+
+// New creates an empty FakeClient
+func New() *FakeClient {
+	return &FakeClient{
+		Errors: make(map[string]error),
+		Now:    time.Now,
+	}
+}
+
+// Factory returns a client.Factory that yields this FakeClient for every host
+func (f *FakeClient) Factory() client.Factory {
+	return func(host string) (client.DiscussionAPI, error) {
+		return f, nil
+	}
+}
+
+// AddRepository adds an empty repository
+func (f *FakeClient) AddRepository(owner, name string) *Repo {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r := &Repo{
+		Info: models.Repository{
+			ID:            f.newID("R"),
+			Name:          name,
+			NameWithOwner: owner + "/" + name,
+			Owner:         &models.User{Login: owner, URL: "https://github.com/" + owner},
+			URL:           fmt.Sprintf("https://github.com/%s/%s", owner, name),
+
+			HasDiscussionsEnabled: true,
+		},
+		Templates: make(map[string]string),
+		fake:      f,
+	}
+	f.repos = append(f.repos, r)
+	return r
+}
+
+// AddCategory adds a discussion category and returns it
+func (r *Repo) AddCategory(name string, answerable bool) models.Category {
+	r.fake.mu.Lock()
+	defer r.fake.mu.Unlock()
+
+	category := models.Category{
+		ID:           r.fake.newID("DIC"),
+		Name:         name,
+		Slug:         categorySlug(name),
+		IsAnswerable: answerable,
+		CreatedAt:    r.fake.Now(),
+		UpdatedAt:    r.fake.Now(),
+	}
+	r.Categories = append(r.Categories, category)
+	return category
+}
+
+// categorySlug derives a category slug the way GitHub does: lower case, with
+// every run of other characters replaced by a single hyphen ("Q&A" is "q-a")
+func categorySlug(name string) string {
+	var b strings.Builder
+	pending := false
+	for _, r := range strings.ToLower(name) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			pending = b.Len() > 0
+			continue
+		}
+		if pending {
+			b.WriteByte('-')
+			pending = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// AddLabel adds a label and returns it
+func (r *Repo) AddLabel(name, color string) models.Label {
+	r.fake.mu.Lock()
+	defer r.fake.mu.Unlock()
+
+	label := models.Label{
+		ID:    r.fake.newID("LA"),
+		Name:  name,
+		Color: color,
+	}
+	r.Labels = append(r.Labels, label)
+	return label
+}
+
+// AddDiscussion stores a discussion, filling in its ID, number, URL,
+// repository, and timestamps when they are unset, and returns the stored copy
+func (r *Repo) AddDiscussion(d models.Discussion) *models.Discussion {
+	r.fake.mu.Lock()
+	defer r.fake.mu.Unlock()
+
+	return r.addDiscussion(d)
+}
+
+// Discussion returns the stored discussion with the given number, or nil
+func (r *Repo) Discussion(number int) *models.Discussion {
+	r.fake.mu.Lock()
+	defer r.fake.mu.Unlock()
+
+	return r.discussion(number)
+}
+
+func (r *Repo) addDiscussion(d models.Discussion) *models.Discussion {
+	if d.ID == "" {
+		d.ID = r.fake.newID("D")
+	}
+	if d.Number == 0 {
+		for _, existing := range r.Discussions {
+			if existing.Number > d.Number {
+				d.Number = existing.Number
+			}
+		}
+		d.Number++
+	}
+	if d.URL == "" {
+		d.URL = fmt.Sprintf("%s/discussions/%d", r.Info.URL, d.Number)
+	}
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = r.fake.Now()
+	}
+	if d.UpdatedAt.IsZero() {
+		d.UpdatedAt = d.CreatedAt
+	}
+	if d.Repository == nil {
+		info := r.Info
+		d.Repository = &info
+	}
+	if d.Comments == nil {
+		d.Comments = &models.CommentConnection{}
+	}
+	if d.Comments.TotalCount == 0 {
+		d.Comments.TotalCount = len(d.Comments.Nodes)
+	}
+
+	r.Discussions = append(r.Discussions, &d)
+	return &d
+}
+
+func (r *Repo) discussion(number int) *models.Discussion {
+	for _, d := range r.Discussions {
+		if d.Number == number {
+			return d
+		}
+	}
+	return nil
+}
+
+// newID generates a node ID with the given type prefix
+func (f *FakeClient) newID(prefix string) string {
+	f.nextID++
+	return fmt.Sprintf("%s_%d", prefix, f.nextID)
+}
+
+// fail returns the context's error if it is done, or else the error
+// configured for a method, if any
+func (f *FakeClient) fail(ctx context.Context, method string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return f.Errors[method]
+}
+
+// notFound returns a client.ErrNotFound error with a formatted message
+func notFound(format string, args ...interface{}) error {
+	return &client.Error{Kind: client.ErrNotFound, Err: fmt.Errorf(format, args...)}
+}
+
+// discussionsDisabled returns a client.ErrDiscussionsDisabled error if
+// discussions are turned off for the repository
+func discussionsDisabled(r *Repo) error {
+	if r.Info.HasDiscussionsEnabled {
+		return nil
+	}
+	return &client.Error{
+		Kind: client.ErrDiscussionsDisabled,
+		Err:  fmt.Errorf("discussions are disabled for %s", r.Info.NameWithOwner),
+	}
+}
+
+// invalidInput returns a client.ErrInvalidInput error with a formatted message
+func invalidInput(format string, args ...interface{}) error {
+	return &client.Error{Kind: client.ErrInvalidInput, Err: fmt.Errorf(format, args...)}
+}
+
+// repo looks up a repository by owner and name
+func (f *FakeClient) repo(owner, name string) (*Repo, error) {
+	for _, r := range f.repos {
+		if strings.EqualFold(r.Info.NameWithOwner, owner+"/"+name) {
+			return r, nil
+		}
+	}
+	return nil, notFound("repository %s/%s not found", owner, name)
+}
+
+// discussionByID looks up a discussion and its repository by node ID
+func (f *FakeClient) discussionByID(id string) (*Repo, *models.Discussion, error) {
+	for _, r := range f.repos {
+		for _, d := range r.Discussions {
+			if d.ID == id {
+				return r, d, nil
+			}
+		}
+	}
+	return nil, nil, notFound("discussion %s not found", id)
+}
+
+// commentByID looks up a comment or reply and its discussion by node ID.
+// For replies, parent is the top-level comment of the thread.
+func (f *FakeClient) commentByID(id string) (comment, parent *models.Comment, discussion *models.Discussion, err error) {
+	for _, r := range f.repos {
+		for _, d := range r.Discussions {
+			for i := range d.Comments.Nodes {
+				c := &d.Comments.Nodes[i]
+				if c.ID == id {
+					return c, nil, d, nil
+				}
+				if c.Replies == nil {
+					continue
+				}
+				for j := range c.Replies.Nodes {
+					if c.Replies.Nodes[j].ID == id {
+						return &c.Replies.Nodes[j], c, d, nil
+					}
+				}
+			}
+		}
+	}
+	return nil, nil, nil, notFound("comment %s not found", id)
+}
+
+// ListDiscussionsWithContext implements client.DiscussionAPI
+func (f *FakeClient) ListDiscussionsWithContext(ctx context.Context, opts models.ListOptions) (*models.DiscussionConnection, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "ListDiscussions"); err != nil {
+		return nil, err
+	}
+
+	r, err := f.repo(opts.Owner, opts.Repo)
+	if err != nil {
+		return nil, err
+	}
+	if err := discussionsDisabled(r); err != nil {
+		return nil, err
+	}
+
+	categoryID := ""
+	if opts.Category != "" {
+		category, err := client.FindCategory(r.Categories, opts.Category)
+		if err != nil {
+			return nil, err
+		}
+		categoryID = category.ID
+	}
+
+	matched := []models.Discussion{}
+	for _, d := range r.Discussions {
+		if !matchesListOptions(d, opts, categoryID) {
+			continue
+		}
+		if len(opts.Fields) == 0 {
+			// Like the list table query, fetch only the number of comments
+			summary := *d
+			summary.Comments = &models.CommentConnection{TotalCount: d.Comments.TotalCount}
+			matched = append(matched, summary)
+			continue
+		}
+		selected, err := selectFields(d, opts.Fields)
+		if err != nil {
+			return nil, err
+		}
+		matched = append(matched, *selected)
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].UpdatedAt.After(matched[j].UpdatedAt)
+	})
+
+	start := 0
+	if opts.After != "" {
+		start, err = strconv.Atoi(opts.After)
+		if err != nil || start < 0 || start > len(matched) {
+			return nil, invalidInput("invalid cursor %q", opts.After)
+		}
+	}
+
+	end := len(matched)
+	if opts.Limit > 0 && start+opts.Limit < end {
+		end = start + opts.Limit
+	}
+
+	return &models.DiscussionConnection{
+		Nodes: matched[start:end],
+		PageInfo: models.PageInfo{
+			HasNextPage: end < len(matched),
+			EndCursor:   strconv.Itoa(end),
+		},
+	}, nil
+}
+
+// matchesListOptions reports whether a discussion passes the list filters,
+// with opts.Category already resolved to categoryID
+func matchesListOptions(d *models.Discussion, opts models.ListOptions, categoryID string) bool {
+	if categoryID != "" && (d.Category == nil || d.Category.ID != categoryID) {
+		return false
+	}
+	if opts.Answered != nil && d.IsAnswered != *opts.Answered {
+		return false
+	}
+	if opts.State == "open" && d.Closed || opts.State == "closed" && !d.Closed {
+		return false
+	}
+	if opts.Author != "" && (d.Author == nil || !strings.EqualFold(d.Author.Login, opts.Author)) {
+		return false
+	}
+	if opts.Search != "" {
+		search := strings.ToLower(opts.Search)
+		if !strings.Contains(strings.ToLower(d.Title), search) && !strings.Contains(strings.ToLower(d.Body), search) {
+			return false
+		}
+	}
+	for _, name := range opts.Labels {
+		if !hasLabel(d, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasLabel reports whether a discussion has a label with the given name
+func hasLabel(d *models.Discussion, name string) bool {
+	if d.Labels == nil {
+		return false
+	}
+	for _, label := range d.Labels.Nodes {
+		if strings.EqualFold(label.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDiscussionWithContext implements client.DiscussionAPI
+func (f *FakeClient) GetDiscussionWithContext(ctx context.Context, opts models.ViewOptions) (*models.Discussion, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "GetDiscussion"); err != nil {
+		return nil, err
+	}
+
+	r, err := f.repo(opts.Owner, opts.Repo)
+	if err != nil {
+		return nil, err
+	}
+	if err := discussionsDisabled(r); err != nil {
+		return nil, err
+	}
+
+	d := r.discussion(opts.Number)
+	if d == nil {
+		return nil, notFound("discussion #%d not found", opts.Number)
+	}
+
+	if len(opts.Fields) > 0 {
+		fields := opts.Fields
+		if opts.ShowComments {
+			fields = append([]string{"comments"}, fields...)
+		}
+		return selectFields(d, fields)
+	}
+
+	result := *d
+	result.Comments = nil
+	if opts.ShowComments {
+		result.Comments = copyComments(d.Comments)
+	}
+	return &result, nil
+}
+
+// selectFields returns a copy of a discussion with only the given JSON fields
+// and its id populated, like the queries the real client builds from
+// ListOptions.Fields and ViewOptions.Fields
+func selectFields(d *models.Discussion, fields []string) (*models.Discussion, error) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	selected := map[string]json.RawMessage{"id": all["id"]}
+	for _, field := range fields {
+		value, ok := all[field]
+		if !ok {
+			return nil, invalidInput("unknown discussion field %q", field)
+		}
+		selected[field] = value
+	}
+
+	data, err = json.Marshal(selected)
+	if err != nil {
+		return nil, err
+	}
+	var result models.Discussion
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// copyComments returns a copy of a comment connection whose nodes can be
+// changed without affecting the stored discussion
+func copyComments(comments *models.CommentConnection) *models.CommentConnection {
+	result := *comments
+	result.Nodes = append([]models.Comment(nil), comments.Nodes...)
+	return &result
+}
+
+// GetDiscussionIDWithContext implements client.DiscussionAPI
+func (f *FakeClient) GetDiscussionIDWithContext(ctx context.Context, owner, repo string, number int) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "GetDiscussionID"); err != nil {
+		return "", err
+	}
+
+	r, err := f.repo(owner, repo)
+	if err != nil {
+		return "", err
+	}
+
+	d := r.discussion(number)
+	if d == nil {
+		return "", notFound("discussion #%d not found", number)
+	}
+	return d.ID, nil
+}
+
+// GetDiscussionCategoriesWithContext implements client.DiscussionAPI
+func (f *FakeClient) GetDiscussionCategoriesWithContext(ctx context.Context, owner, repo string) ([]models.Category, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "GetDiscussionCategories"); err != nil {
+		return nil, err
+	}
+
+	r, err := f.repo(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return append([]models.Category(nil), r.Categories...), nil
+}
+
+// GetDiscussionTemplateWithContext implements client.DiscussionAPI
+func (f *FakeClient) GetDiscussionTemplateWithContext(ctx context.Context, owner, repo, categorySlug string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "GetDiscussionTemplate"); err != nil {
+		return nil, err
+	}
+
+	r, err := f.repo(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	template, ok := r.Templates[categorySlug]
+	if !ok {
+		return nil, nil
+	}
+	return []byte(template), nil
+}
+
+// GetRepositoryInfoWithContext implements client.DiscussionAPI
+func (f *FakeClient) GetRepositoryInfoWithContext(ctx context.Context, owner, repo string) (*models.Repository, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "GetRepositoryInfo"); err != nil {
+		return nil, err
+	}
+
+	r, err := f.repo(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	info := r.Info
+	return &info, nil
+}
+
+// GetLabelIDsWithContext implements client.DiscussionAPI
+func (f *FakeClient) GetLabelIDsWithContext(ctx context.Context, owner, repo string, names []string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "GetLabelIDs"); err != nil {
+		return nil, err
+	}
+
+	r, err := f.repo(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, label := range r.Labels {
+			if strings.EqualFold(label.Name, name) {
+				ids = append(ids, label.ID)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, notFound("label %q not found in %s/%s", name, owner, repo)
+		}
+	}
+	return ids, nil
+}
+
+// GetDiscussionCommentWithContext implements client.DiscussionAPI
+func (f *FakeClient) GetDiscussionCommentWithContext(ctx context.Context, commentID string) (*models.Comment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "GetDiscussionComment"); err != nil {
+		return nil, err
+	}
+
+	comment, parent, d, err := f.commentByID(commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := *comment
+	if parent != nil {
+		result.ReplyTo = &models.Comment{ID: parent.ID, URL: parent.URL}
+	}
+	result.Discussion = &models.Discussion{
+		ID:         d.ID,
+		Number:     d.Number,
+		Title:      d.Title,
+		URL:        d.URL,
+		Category:   d.Category,
+		Repository: d.Repository,
+	}
+	return &result, nil
+}
+
+// FindDiscussionCommentWithContext implements client.DiscussionAPI
+func (f *FakeClient) FindDiscussionCommentWithContext(ctx context.Context, owner, repo string, number, databaseID int) (*models.Comment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "FindDiscussionComment"); err != nil {
+		return nil, err
+	}
+
+	r, err := f.repo(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	d := r.discussion(number)
+	if d == nil {
+		return nil, notFound("discussion #%d not found", number)
+	}
+
+	for _, comment := range d.Comments.Nodes {
+		if comment.DatabaseID == databaseID {
+			return &comment, nil
+		}
+		if comment.Replies == nil {
+			continue
+		}
+		for _, reply := range comment.Replies.Nodes {
+			if reply.DatabaseID == databaseID {
+				reply.ReplyTo = &models.Comment{ID: comment.ID, URL: comment.URL}
+				return &reply, nil
+			}
+		}
+	}
+	return nil, notFound("comment %d not found in discussion #%d", databaseID, number)
+}
+
+// GetRateLimitWithContext implements client.DiscussionAPI
+func (f *FakeClient) GetRateLimitWithContext(ctx context.Context) (*models.RateLimit, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "GetRateLimit"); err != nil {
+		return nil, err
+	}
+
+	if f.RateLimit != nil {
+		rateLimit := *f.RateLimit
+		return &rateLimit, nil
+	}
+	return &models.RateLimit{
+		Limit:     5000,
+		Remaining: 5000,
+		ResetAt:   f.Now().Add(time.Hour),
+	}, nil
+}
+
+// CreateDiscussionWithContext implements client.DiscussionAPI
+func (f *FakeClient) CreateDiscussionWithContext(ctx context.Context, opts models.CreateOptions) (*models.Discussion, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "CreateDiscussion"); err != nil {
+		return nil, err
+	}
+
+	for _, r := range f.repos {
+		if r.Info.ID != opts.RepositoryID {
+			continue
+		}
+		for i := range r.Categories {
+			if r.Categories[i].ID != opts.CategoryID {
+				continue
+			}
+			category := r.Categories[i]
+			d := r.addDiscussion(models.Discussion{
+				Title:           opts.Title,
+				Body:            opts.Body,
+				Category:        &category,
+				ViewerCanDelete: true,
+				ViewerCanUpdate: true,
+				ViewerDidAuthor: true,
+			})
+			result := *d
+			return &result, nil
+		}
+		return nil, notFound("category %s not found", opts.CategoryID)
+	}
+	return nil, notFound("repository %s not found", opts.RepositoryID)
+}
+
+// UpdateDiscussionWithContext implements client.DiscussionAPI
+func (f *FakeClient) UpdateDiscussionWithContext(ctx context.Context, opts models.UpdateOptions) (*models.Discussion, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "UpdateDiscussion"); err != nil {
+		return nil, err
+	}
+
+	r, d, err := f.discussionByID(opts.DiscussionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Title != "" {
+		d.Title = opts.Title
+	}
+	if opts.Body != "" {
+		d.Body = opts.Body
+	}
+	if opts.CategoryID != "" {
+		found := false
+		for i := range r.Categories {
+			if r.Categories[i].ID == opts.CategoryID {
+				category := r.Categories[i]
+				d.Category = &category
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, notFound("category %s not found", opts.CategoryID)
+		}
+	}
+	d.UpdatedAt = f.Now()
+
+	result := *d
+	return &result, nil
+}
+
+// CloseDiscussionWithContext implements client.DiscussionAPI
+func (f *FakeClient) CloseDiscussionWithContext(ctx context.Context, discussionID, reason string) (*models.Discussion, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "CloseDiscussion"); err != nil {
+		return nil, err
+	}
+
+	_, d, err := f.discussionByID(discussionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reason == "" {
+		reason = "RESOLVED"
+	}
+	now := f.Now()
+	d.Closed = true
+	d.ClosedAt = &now
+	d.StateReason = &reason
+
+	result := *d
+	return &result, nil
+}
+
+// ReopenDiscussionWithContext implements client.DiscussionAPI
+func (f *FakeClient) ReopenDiscussionWithContext(ctx context.Context, discussionID string) (*models.Discussion, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "ReopenDiscussion"); err != nil {
+		return nil, err
+	}
+
+	_, d, err := f.discussionByID(discussionID)
+	if err != nil {
+		return nil, err
+	}
+
+	reason := "REOPENED"
+	d.Closed = false
+	d.ClosedAt = nil
+	d.StateReason = &reason
+
+	result := *d
+	return &result, nil
+}
+
+// LockDiscussionWithContext implements client.DiscussionAPI
+func (f *FakeClient) LockDiscussionWithContext(ctx context.Context, discussionID, reason string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "LockDiscussion"); err != nil {
+		return err
+	}
+
+	_, d, err := f.discussionByID(discussionID)
+	if err != nil {
+		return err
+	}
+
+	d.Locked = true
+	d.ActiveLockReason = nil
+	if reason != "" {
+		d.ActiveLockReason = &reason
+	}
+	return nil
+}
+
+// UnlockDiscussionWithContext implements client.DiscussionAPI
+func (f *FakeClient) UnlockDiscussionWithContext(ctx context.Context, discussionID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "UnlockDiscussion"); err != nil {
+		return err
+	}
+
+	_, d, err := f.discussionByID(discussionID)
+	if err != nil {
+		return err
+	}
+
+	d.Locked = false
+	d.ActiveLockReason = nil
+	return nil
+}
+
+// DeleteDiscussionWithContext implements client.DiscussionAPI
+func (f *FakeClient) DeleteDiscussionWithContext(ctx context.Context, discussionID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "DeleteDiscussion"); err != nil {
+		return err
+	}
+
+	r, _, err := f.discussionByID(discussionID)
+	if err != nil {
+		return err
+	}
+
+	for i, d := range r.Discussions {
+		if d.ID == discussionID {
+			r.Discussions = append(r.Discussions[:i], r.Discussions[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// AddLabelsWithContext implements client.DiscussionAPI
+func (f *FakeClient) AddLabelsWithContext(ctx context.Context, labelableID string, labelIDs []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "AddLabels"); err != nil {
+		return err
+	}
+
+	r, d, err := f.discussionByID(labelableID)
+	if err != nil {
+		return err
+	}
+
+	if d.Labels == nil {
+		d.Labels = &models.LabelConnection{}
+	}
+	for _, id := range labelIDs {
+		label, err := r.label(id)
+		if err != nil {
+			return err
+		}
+		if !hasLabel(d, label.Name) {
+			d.Labels.Nodes = append(d.Labels.Nodes, label)
+		}
+	}
+	return nil
+}
+
+// RemoveLabelsWithContext implements client.DiscussionAPI
+func (f *FakeClient) RemoveLabelsWithContext(ctx context.Context, labelableID string, labelIDs []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "RemoveLabels"); err != nil {
+		return err
+	}
+
+	r, d, err := f.discussionByID(labelableID)
+	if err != nil {
+		return err
+	}
+
+	if d.Labels == nil {
+		return nil
+	}
+	for _, id := range labelIDs {
+		label, err := r.label(id)
+		if err != nil {
+			return err
+		}
+		var kept []models.Label
+		for _, existing := range d.Labels.Nodes {
+			if !strings.EqualFold(existing.Name, label.Name) {
+				kept = append(kept, existing)
+			}
+		}
+		d.Labels.Nodes = kept
+	}
+	return nil
+}
+
+// label looks up a repository label by node ID
+func (r *Repo) label(id string) (models.Label, error) {
+	for _, label := range r.Labels {
+		if label.ID == id {
+			return label, nil
+		}
+	}
+	return models.Label{}, notFound("label %s not found", id)
+}
+
+// AddDiscussionCommentWithContext implements client.DiscussionAPI
+func (f *FakeClient) AddDiscussionCommentWithContext(ctx context.Context, opts models.CommentOptions) (*models.Comment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "AddDiscussionComment"); err != nil {
+		return nil, err
+	}
+
+	_, d, err := f.discussionByID(opts.DiscussionID)
+	if err != nil {
+		return nil, err
+	}
+
+	id := f.newID("DC")
+	databaseID := f.nextID
+	comment := models.Comment{
+		ID:              id,
+		DatabaseID:      databaseID,
+		Body:            opts.Body,
+		BodyText:        opts.Body,
+		CreatedAt:       f.Now(),
+		UpdatedAt:       f.Now(),
+		URL:             fmt.Sprintf("%s#discussioncomment-%d", d.URL, databaseID),
+		ViewerCanDelete: true,
+	}
+
+	if opts.ReplyToID == "" {
+		comment.ViewerCanMarkAsAnswer = d.Category != nil && d.Category.IsAnswerable
+		comment.Replies = &models.CommentConnection{}
+		d.Comments.Nodes = append(d.Comments.Nodes, comment)
+		d.Comments.TotalCount++
+		result := comment
+		return &result, nil
+	}
+
+	parent, grandparent, commentDiscussion, err := f.commentByID(opts.ReplyToID)
+	if err != nil {
+		return nil, err
+	}
+	if commentDiscussion.ID != d.ID {
+		return nil, invalidInput("comment %s does not belong to discussion %s", opts.ReplyToID, d.ID)
+	}
+	if grandparent != nil {
+		return nil, invalidInput("comment %s is a reply and cannot be replied to", opts.ReplyToID)
+	}
+
+	comment.ReplyTo = &models.Comment{ID: parent.ID, URL: parent.URL}
+	if parent.Replies == nil {
+		parent.Replies = &models.CommentConnection{}
+	}
+	parent.Replies.Nodes = append(parent.Replies.Nodes, comment)
+	parent.Replies.TotalCount++
+
+	result := comment
+	return &result, nil
+}
+
+// DeleteDiscussionCommentWithContext implements client.DiscussionAPI
+func (f *FakeClient) DeleteDiscussionCommentWithContext(ctx context.Context, commentID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "DeleteDiscussionComment"); err != nil {
+		return err
+	}
+
+	_, parent, d, err := f.commentByID(commentID)
+	if err != nil {
+		return err
+	}
+
+	connection := d.Comments
+	if parent != nil {
+		connection = parent.Replies
+	}
+	for i, c := range connection.Nodes {
+		if c.ID == commentID {
+			connection.Nodes = append(connection.Nodes[:i], connection.Nodes[i+1:]...)
+			connection.TotalCount--
+			break
+		}
+	}
+	return nil
+}
+
+// MarkCommentAsAnswerWithContext implements client.DiscussionAPI
+func (f *FakeClient) MarkCommentAsAnswerWithContext(ctx context.Context, commentID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "MarkCommentAsAnswer"); err != nil {
+		return err
+	}
+
+	comment, parent, d, err := f.commentByID(commentID)
+	if err != nil {
+		return err
+	}
+	if parent != nil {
+		return invalidInput("replies cannot be marked as the answer")
+	}
+	if d.Category == nil || !d.Category.IsAnswerable {
+		return invalidInput("discussion #%d is not in an answerable category", d.Number)
+	}
+
+	for i := range d.Comments.Nodes {
+		d.Comments.Nodes[i].IsAnswer = false
+	}
+	comment.IsAnswer = true
+	comment.ViewerCanUnmarkAsAnswer = true
+
+	now := f.Now()
+	answer := *comment
+	d.Answer = &answer
+	d.AnswerChosenAt = &now
+	d.IsAnswered = true
+	return nil
+}
+
+// UnmarkCommentAsAnswerWithContext implements client.DiscussionAPI
+func (f *FakeClient) UnmarkCommentAsAnswerWithContext(ctx context.Context, commentID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "UnmarkCommentAsAnswer"); err != nil {
+		return err
+	}
+
+	comment, _, d, err := f.commentByID(commentID)
+	if err != nil {
+		return err
+	}
+	if !comment.IsAnswer {
+		return invalidInput("comment %s is not the answer", commentID)
+	}
+
+	comment.IsAnswer = false
+	comment.ViewerCanUnmarkAsAnswer = false
+	d.Answer = nil
+	d.AnswerChosenAt = nil
+	d.IsAnswered = false
+	return nil
+}
+
+// SetDiscussionsEnabledWithContext implements client.DiscussionAPI
+func (f *FakeClient) SetDiscussionsEnabledWithContext(ctx context.Context, owner, repo string, enabled bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.fail(ctx, "SetDiscussionsEnabled"); err != nil {
+		return err
+	}
+
+	r, err := f.repo(owner, repo)
+	if err != nil {
+		return err
+	}
+
+	r.Info.HasDiscussionsEnabled = enabled
+	return nil
+}
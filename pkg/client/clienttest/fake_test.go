@@ -0,0 +1,184 @@
+package clienttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/harakeishi/gh-discussion/pkg/client"
+	"github.com/harakeishi/gh-discussion/pkg/models"
+)
+
+func TestCategorySlug(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "General", want: "general"},
+		{name: "Q&A", want: "q-a"},
+		{name: "Show and tell", want: "show-and-tell"},
+		{name: "  Ideas & Feedback!  ", want: "ideas-feedback"},
+		{name: "Release_2024", want: "release-2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := New().AddRepository("octo", "hello")
+			if got := repo.AddCategory(tt.name, false).Slug; got != tt.want {
+				t.Errorf("AddCategory(%q).Slug = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListDiscussionsFields(t *testing.T) {
+	fake := New()
+	repo := fake.AddRepository("octo", "hello")
+	repo.AddDiscussion(models.Discussion{
+		Title:  "Hello",
+		Body:   "World",
+		Author: &models.User{Login: "alice", URL: "https://github.com/alice"},
+		Comments: &models.CommentConnection{
+			Nodes: []models.Comment{{ID: "DC_1", Body: "Hi"}},
+		},
+	})
+
+	ctx := context.Background()
+
+	summary, err := fake.ListDiscussionsWithContext(ctx, models.ListOptions{Owner: "octo", Repo: "hello", State: "open"})
+	if err != nil {
+		t.Fatalf("ListDiscussionsWithContext() error = %v", err)
+	}
+	if got := summary.Nodes[0].Comments; got.TotalCount != 1 || len(got.Nodes) != 0 {
+		t.Errorf("summary comments = %+v, want only a total count of 1", got)
+	}
+
+	selected, err := fake.ListDiscussionsWithContext(ctx, models.ListOptions{
+		Owner:  "octo",
+		Repo:   "hello",
+		State:  "open",
+		Fields: []string{"title", "author"},
+	})
+	if err != nil {
+		t.Fatalf("ListDiscussionsWithContext() error = %v", err)
+	}
+
+	data, err := json.Marshal(selected.Nodes[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]json.RawMessage
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if string(got["title"]) != `"Hello"` {
+		t.Errorf("title = %s, want \"Hello\"", got["title"])
+	}
+	if string(got["body"]) != `""` {
+		t.Errorf("unrequested body = %s, want it to be empty", got["body"])
+	}
+	if string(got["comments"]) != "null" {
+		t.Errorf("unrequested comments = %s, want null", got["comments"])
+	}
+
+	_, err = fake.ListDiscussionsWithContext(ctx, models.ListOptions{
+		Owner:  "octo",
+		Repo:   "hello",
+		State:  "open",
+		Fields: []string{"nope"},
+	})
+	if !errors.Is(err, client.ErrInvalidInput) {
+		t.Errorf("unknown field error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestCommentReplies(t *testing.T) {
+	fake := New()
+	repo := fake.AddRepository("octo", "hello")
+	d := repo.AddDiscussion(models.Discussion{Title: "Hello"})
+
+	ctx := context.Background()
+
+	parent, err := fake.AddDiscussionCommentWithContext(ctx, models.CommentOptions{DiscussionID: d.ID, Body: "Parent"})
+	if err != nil {
+		t.Fatalf("AddDiscussionCommentWithContext() error = %v", err)
+	}
+	reply, err := fake.AddDiscussionCommentWithContext(ctx, models.CommentOptions{DiscussionID: d.ID, Body: "Reply", ReplyToID: parent.ID})
+	if err != nil {
+		t.Fatalf("AddDiscussionCommentWithContext() error = %v", err)
+	}
+
+	_, err = fake.AddDiscussionCommentWithContext(ctx, models.CommentOptions{DiscussionID: d.ID, Body: "Nested", ReplyToID: reply.ID})
+	if !errors.Is(err, client.ErrInvalidInput) {
+		t.Errorf("reply to a reply error = %v, want ErrInvalidInput", err)
+	}
+
+	got, err := fake.GetDiscussionCommentWithContext(ctx, reply.ID)
+	if err != nil {
+		t.Fatalf("GetDiscussionCommentWithContext() error = %v", err)
+	}
+	if got.ReplyTo == nil || got.ReplyTo.ID != parent.ID {
+		t.Errorf("reply ReplyTo = %+v, want %s", got.ReplyTo, parent.ID)
+	}
+	if got.Discussion == nil || got.Discussion.Number != d.Number {
+		t.Errorf("reply Discussion = %+v, want #%d", got.Discussion, d.Number)
+	}
+
+	found, err := fake.FindDiscussionCommentWithContext(ctx, "octo", "hello", d.Number, reply.DatabaseID)
+	if err != nil {
+		t.Fatalf("FindDiscussionCommentWithContext() error = %v", err)
+	}
+	if found.ID != reply.ID {
+		t.Errorf("found comment %s, want %s", found.ID, reply.ID)
+	}
+
+	stored := repo.Discussion(d.Number)
+	if stored.Comments.TotalCount != 1 || stored.Comments.Nodes[0].Replies.TotalCount != 1 {
+		t.Errorf("stored comments = %+v, want one comment with one reply", stored.Comments)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	fake := New()
+	fake.AddRepository("octo", "hello")
+
+	injected := &client.Error{Kind: client.ErrRateLimited, Err: errors.New("slow down")}
+	fake.Errors["GetRepositoryInfo"] = injected
+
+	if _, err := fake.GetRepositoryInfoWithContext(context.Background(), "octo", "hello"); !errors.Is(err, client.ErrRateLimited) {
+		t.Errorf("GetRepositoryInfoWithContext() error = %v, want the injected error", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := fake.GetDiscussionCategoriesWithContext(ctx, "octo", "hello"); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetDiscussionCategoriesWithContext() error = %v, want context.Canceled", err)
+	}
+
+	if _, err := fake.GetRepositoryInfoWithContext(context.Background(), "octo", "missing"); !errors.Is(err, client.ErrRateLimited) {
+		t.Errorf("GetRepositoryInfoWithContext() error = %v, want the injected error first", err)
+	}
+	delete(fake.Errors, "GetRepositoryInfo")
+	if _, err := fake.GetRepositoryInfoWithContext(context.Background(), "octo", "missing"); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("GetRepositoryInfoWithContext() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestListDiscussionsEmpty(t *testing.T) {
+	fake := New()
+	fake.AddRepository("octo", "hello")
+
+	result, err := fake.ListDiscussionsWithContext(context.Background(), models.ListOptions{Owner: "octo", Repo: "hello", State: "open"})
+	if err != nil {
+		t.Fatalf("ListDiscussionsWithContext() error = %v", err)
+	}
+
+	data, err := json.Marshal(result.Nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "[]" {
+		t.Errorf("empty result encodes as %s, want []", data)
+	}
+}
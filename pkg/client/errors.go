@@ -0,0 +1,135 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// Error kinds returned by the client. Test for them with errors.Is, for
+// example errors.Is(err, client.ErrNotFound).
+var (
+	// ErrNotFound means the repository, discussion, comment, or label does not
+	// exist or is not visible to the authenticated user
+	ErrNotFound = errors.New("not found")
+	// ErrForbidden means the authenticated user is not allowed to perform the
+	// operation, or the authentication token is missing or invalid
+	ErrForbidden = errors.New("forbidden")
+	// ErrRateLimited means GitHub rate limited the request and retrying did not help
+	ErrRateLimited = errors.New("rate limited")
+	// ErrInvalidInput means the request was rejected because of invalid arguments
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrDiscussionsDisabled means discussions are not enabled for the repository
+	ErrDiscussionsDisabled = errors.New("discussions disabled")
+)
+
+// Error is an error with a kind that classifies its cause
+type Error struct {
+	// Kind is one of the Err* kinds defined in this package
+	Kind error
+	// Err is the underlying error
+	Err error
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap allows errors.Is and errors.As to match both the kind and the
+// underlying error
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// newError creates an Error of the given kind with a formatted message
+func newError(kind error, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+// checkDiscussionsEnabled returns an ErrDiscussionsDisabled error when a
+// query reported that discussions are turned off for the repository. A nil
+// enabled means the repository was not returned and is not checked.
+func checkDiscussionsEnabled(enabled *bool, owner, repo string) error {
+	if enabled != nil && !*enabled {
+		return newError(ErrDiscussionsDisabled, "discussions are disabled for %s/%s", owner, repo)
+	}
+	return nil
+}
+
+// classifyError attaches a kind to errors returned by the GitHub API based on
+// GraphQL error types and HTTP status codes. Errors that cannot be classified
+// are returned unchanged.
+func classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var classified *Error
+	if errors.As(err, &classified) {
+		return err
+	}
+
+	if kind := errorKind(err); kind != nil {
+		return &Error{Kind: kind, Err: err}
+	}
+	return err
+}
+
+// errorKind returns the kind of an API error, or nil if it is unknown
+func errorKind(err error) error {
+	if isDiscussionsDisabledMessage(err.Error()) {
+		return ErrDiscussionsDisabled
+	}
+
+	var gqlErr *api.GraphQLError
+	if errors.As(err, &gqlErr) {
+		for _, e := range gqlErr.Errors {
+			switch e.Type {
+			case "NOT_FOUND":
+				return ErrNotFound
+			case "FORBIDDEN", "INSUFFICIENT_SCOPES":
+				return ErrForbidden
+			case "RATE_LIMITED":
+				return ErrRateLimited
+			case "UNPROCESSABLE", "ARGUMENT_ERROR":
+				return ErrInvalidInput
+			}
+		}
+		return nil
+	}
+
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.StatusCode {
+		case http.StatusNotFound:
+			return ErrNotFound
+		case http.StatusUnauthorized:
+			return ErrForbidden
+		case http.StatusForbidden:
+			if httpErr.Headers.Get("X-Ratelimit-Remaining") == "0" ||
+				strings.Contains(strings.ToLower(httpErr.Message), "rate limit") {
+				return ErrRateLimited
+			}
+			return ErrForbidden
+		case http.StatusTooManyRequests:
+			return ErrRateLimited
+		case http.StatusUnprocessableEntity:
+			return ErrInvalidInput
+		}
+	}
+
+	return nil
+}
+
+// isDiscussionsDisabledMessage reports whether an API error message says that
+// discussions are turned off for a repository
+func isDiscussionsDisabledMessage(msg string) bool {
+	msg = strings.ToLower(msg)
+	return strings.Contains(msg, "discussions are disabled") ||
+		strings.Contains(msg, "does not have discussions enabled") ||
+		strings.Contains(msg, "discussions are not enabled")
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+func TestClassifyError(t *testing.T) {
+	gqlErr := func(types ...string) error {
+		err := &api.GraphQLError{}
+		for _, typ := range types {
+			err.Errors = append(err.Errors, api.GraphQLErrorItem{Type: typ, Message: typ})
+		}
+		return err
+	}
+	httpErr := func(status int, message string, header http.Header) error {
+		return &api.HTTPError{StatusCode: status, Message: message, Headers: header}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "graphql not found", err: gqlErr("NOT_FOUND"), want: ErrNotFound},
+		{name: "graphql forbidden", err: gqlErr("FORBIDDEN"), want: ErrForbidden},
+		{name: "graphql insufficient scopes", err: gqlErr("INSUFFICIENT_SCOPES"), want: ErrForbidden},
+		{name: "graphql rate limited", err: gqlErr("RATE_LIMITED"), want: ErrRateLimited},
+		{name: "graphql unprocessable", err: gqlErr("UNPROCESSABLE"), want: ErrInvalidInput},
+		{name: "graphql argument error", err: gqlErr("ARGUMENT_ERROR"), want: ErrInvalidInput},
+		{name: "first known graphql type wins", err: gqlErr("SOMETHING", "NOT_FOUND", "FORBIDDEN"), want: ErrNotFound},
+		{name: "unknown graphql type", err: gqlErr("SOMETHING")},
+		{name: "discussions disabled message", err: errors.New("Repository does not have discussions enabled."), want: ErrDiscussionsDisabled},
+		{name: "http not found", err: httpErr(http.StatusNotFound, "Not Found", http.Header{}), want: ErrNotFound},
+		{name: "http unauthorized", err: httpErr(http.StatusUnauthorized, "Bad credentials", http.Header{}), want: ErrForbidden},
+		{name: "http forbidden", err: httpErr(http.StatusForbidden, "Must have admin rights", http.Header{}), want: ErrForbidden},
+		{name: "http primary rate limit", err: httpErr(http.StatusForbidden, "", http.Header{"X-Ratelimit-Remaining": {"0"}}), want: ErrRateLimited},
+		{name: "http secondary rate limit", err: httpErr(http.StatusForbidden, "You have exceeded a secondary rate limit", http.Header{}), want: ErrRateLimited},
+		{name: "http too many requests", err: httpErr(http.StatusTooManyRequests, "", http.Header{}), want: ErrRateLimited},
+		{name: "http unprocessable", err: httpErr(http.StatusUnprocessableEntity, "Validation Failed", http.Header{}), want: ErrInvalidInput},
+		{name: "http server error", err: httpErr(http.StatusBadGateway, "Bad Gateway", http.Header{})},
+		{name: "wrapped", err: fmt.Errorf("failed to get discussion: %w", gqlErr("NOT_FOUND")), want: ErrNotFound},
+		{name: "other error", err: errors.New("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyError(tt.err)
+			if !errors.Is(got, tt.err) {
+				t.Errorf("classifyError() = %v, which no longer wraps the original error", got)
+			}
+
+			var classified *Error
+			if tt.want == nil {
+				if errors.As(got, &classified) {
+					t.Errorf("classifyError() kind = %v, want none", classified.Kind)
+				}
+				return
+			}
+			if !errors.Is(got, tt.want) {
+				t.Errorf("classifyError() = %v, want kind %v", got, tt.want)
+			}
+		})
+	}
+
+	if classifyError(nil) != nil {
+		t.Error("classifyError(nil) != nil")
+	}
+
+	// Errors that already have a kind keep it
+	err := newError(ErrInvalidInput, "bad")
+	if got := classifyError(err); got != err {
+		t.Errorf("classifyError() = %v, want the classified error unchanged", got)
+	}
+}
+
+func TestCheckDiscussionsEnabled(t *testing.T) {
+	enabled, disabled := true, false
+	if err := checkDiscussionsEnabled(nil, "octo", "hello"); err != nil {
+		t.Errorf("checkDiscussionsEnabled(nil) error = %v", err)
+	}
+	if err := checkDiscussionsEnabled(&enabled, "octo", "hello"); err != nil {
+		t.Errorf("checkDiscussionsEnabled(true) error = %v", err)
+	}
+	if err := checkDiscussionsEnabled(&disabled, "octo", "hello"); !errors.Is(err, ErrDiscussionsDisabled) {
+		t.Errorf("checkDiscussionsEnabled(false) error = %v, want ErrDiscussionsDisabled", err)
+	}
+}
+
+func TestNewGitHubClientWithoutToken(t *testing.T) {
+	// Hide every token source: the environment, the gh config, and gh itself
+	for _, name := range []string{"GH_TOKEN", "GITHUB_TOKEN", "GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN", RecordEnv, ReplayEnv} {
+		t.Setenv(name, "")
+	}
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+	t.Setenv("GH_PATH", "")
+	t.Setenv("PATH", "")
+
+	_, err := NewGitHubClientWithOptions(Options{Host: "github.com"})
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("NewGitHubClientWithOptions() error = %v, want ErrForbidden", err)
+	}
+
+	t.Setenv("GH_TOKEN", "token")
+	if _, err := NewGitHubClientWithOptions(Options{Host: "github.com"}); err != nil {
+		t.Errorf("NewGitHubClientWithOptions() with GH_TOKEN error = %v", err)
+	}
+
+	// Replaying fixtures needs no token
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv(ReplayEnv, t.TempDir())
+	if _, err := NewGitHubClientWithOptions(Options{Host: "github.com"}); err != nil {
+		t.Errorf("NewGitHubClientWithOptions() while replaying error = %v", err)
+	}
+}
@@ -0,0 +1,295 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// discussionField describes how to fetch one JSON field of models.Discussion
+type discussionField struct {
+	// selection is the GraphQL selection that populates the field
+	selection string
+	// fragments are the fragment definitions used by the selection
+	fragments []string
+	// subfields are the JSON fields the selection fetches inside an object
+	// field; nil for scalar fields
+	subfields subfields
+}
+
+// subfields is the set of JSON fields fetched inside an object, keyed by name.
+// Scalar fields map to nil.
+type subfields map[string]subfields
+
+// connection returns the subfields of a connection whose nodes have the
+// given subfields
+func connection(nodes subfields) subfields {
+	return subfields{
+		"totalCount": nil,
+		"pageInfo":   {"hasNextPage": nil, "endCursor": nil},
+		"nodes":      nodes,
+	}
+}
+
+// Subfields fetched by the shared selections and fragments below. Keep them in
+// step with the selections; TestSubfieldsAreSelected checks every name.
+var (
+	actorSubfields = subfields{"login": nil, "url": nil, "avatarUrl": nil, "name": nil, "email": nil}
+
+	reactionGroupSubfields = subfields{"content": nil, "users": {"totalCount": nil}}
+
+	replySubfields = subfields{
+		"id": nil, "databaseId": nil, "body": nil, "bodyText": nil, "createdAt": nil, "updatedAt": nil,
+		"author": actorSubfields, "authorAssociation": nil, "isAnswer": nil, "url": nil,
+		"replyTo": {"id": nil, "url": nil},
+	}
+
+	commentSubfields = subfields{
+		"id": nil, "databaseId": nil, "body": nil, "bodyText": nil, "bodyHTML": nil,
+		"createdAt": nil, "updatedAt": nil, "publishedAt": nil,
+		"author": actorSubfields, "authorAssociation": nil, "upvoteCount": nil, "isAnswer": nil,
+		"isMinimized": nil, "minimizedReason": nil, "reactionGroups": reactionGroupSubfields, "url": nil,
+		"viewerCanMarkAsAnswer": nil, "viewerCanUnmarkAsAnswer": nil,
+		"replies": connection(replySubfields),
+	}
+)
+
+// actorSelection selects the fields of models.User for an Actor
+const actorSelection = `{
+	login
+	url
+	avatarUrl
+	... on User {
+		name
+		email
+	}
+}`
+
+// discussionFields maps each JSON field of models.Discussion to the GraphQL
+// selection that populates it. Queries are built from the requested fields
+// so that only what is needed is fetched. This is also the set of fields
+// accepted by --json, so add an entry here for every field added to
+// models.Discussion; TestDiscussionFieldsMatchModel fails until you do.
+var discussionFields = map[string]discussionField{
+	"id":           {selection: "id"},
+	"number":       {selection: "number"},
+	"title":        {selection: "title"},
+	"body":         {selection: "body"},
+	"bodyText":     {selection: "bodyText"},
+	"bodyHTML":     {selection: "bodyHTML"},
+	"createdAt":    {selection: "createdAt"},
+	"updatedAt":    {selection: "updatedAt"},
+	"publishedAt":  {selection: "publishedAt"},
+	"lastEditedAt": {selection: "lastEditedAt"},
+	"author":       {selection: "author " + actorSelection, subfields: actorSubfields},
+	"category": {
+		selection: "category { id name slug description emoji emojiHTML isAnswerable createdAt updatedAt }",
+		subfields: subfields{
+			"id": nil, "name": nil, "slug": nil, "description": nil, "emoji": nil, "emojiHTML": nil,
+			"isAnswerable": nil, "createdAt": nil, "updatedAt": nil,
+		},
+	},
+	"repository": {
+		selection: "repository { id name nameWithOwner owner { login url } url description }",
+		subfields: subfields{
+			"id": nil, "name": nil, "nameWithOwner": nil, "owner": {"login": nil, "url": nil},
+			"url": nil, "description": nil,
+		},
+	},
+	"url":              {selection: "url"},
+	"resourcePath":     {selection: "resourcePath"},
+	"closed":           {selection: "closed"},
+	"closedAt":         {selection: "closedAt"},
+	"stateReason":      {selection: "stateReason"},
+	"locked":           {selection: "locked"},
+	"activeLockReason": {selection: "activeLockReason"},
+	"answerChosenAt":   {selection: "answerChosenAt"},
+	"answerChosenBy":   {selection: "answerChosenBy " + actorSelection, subfields: actorSubfields},
+	"answer": {
+		selection: `answer {
+			id
+			databaseId
+			body
+			bodyText
+			createdAt
+			author ` + actorSelection + `
+			isAnswer
+			url
+		}`,
+		subfields: subfields{
+			"id": nil, "databaseId": nil, "body": nil, "bodyText": nil, "createdAt": nil,
+			"author": actorSubfields, "isAnswer": nil, "url": nil,
+		},
+	},
+	"isAnswered": {selection: "isAnswered"},
+	"comments": {
+		selection: `comments(first: 100) {
+			totalCount
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+			nodes {
+				...commentFields
+			}
+		}`,
+		fragments: []string{commentFragment, replyFragment},
+		subfields: connection(commentSubfields),
+	},
+	"labels": {
+		selection: "labels(first: 10) { nodes { id name color } }",
+		subfields: subfields{"nodes": {"id": nil, "name": nil, "color": nil}},
+	},
+	"reactionGroups":      {selection: "reactionGroups { content users { totalCount } }", subfields: reactionGroupSubfields},
+	"viewerCanDelete":     {selection: "viewerCanDelete"},
+	"viewerCanReact":      {selection: "viewerCanReact"},
+	"viewerCanSubscribe":  {selection: "viewerCanSubscribe"},
+	"viewerCanUpdate":     {selection: "viewerCanUpdate"},
+	"viewerDidAuthor":     {selection: "viewerDidAuthor"},
+	"viewerSubscription":  {selection: "viewerSubscription"},
+	"authorAssociation":   {selection: "authorAssociation"},
+	"createdViaEmail":     {selection: "createdViaEmail"},
+	"databaseId":          {selection: "databaseId"},
+	"editor":              {selection: "editor " + actorSelection, subfields: actorSubfields},
+	"includesCreatedEdit": {selection: "includesCreatedEdit"},
+}
+
+// commentCountSelection fetches only the number of comments, which is all
+// the list table needs
+const commentCountSelection = "comments(first: 0) { totalCount }"
+
+// defaultListFields are fetched by ListDiscussions when no fields are
+// requested. Together with commentCountSelection they cover the list table.
+var defaultListFields = []string{
+	"number", "title", "bodyText", "createdAt", "updatedAt", "author", "category", "url",
+	"closed", "closedAt", "stateReason", "answerChosenAt", "isAnswered", "labels",
+}
+
+// commentsPageSize is the number of discussions fetched per page when their
+// comments are requested, keeping nested comments and replies under the
+// GraphQL node limit
+const commentsPageSize = 10
+
+// DiscussionFieldNames returns the JSON fields of a discussion that can be
+// requested through ListOptions.Fields and ViewOptions.Fields, sorted by name
+func DiscussionFieldNames() []string {
+	names := make([]string, 0, len(discussionFields))
+	for name := range discussionFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// defaultViewFields returns the fields fetched by GetDiscussion when no fields
+// are requested: every field except comments, which are only fetched on request
+func defaultViewFields(withComments bool) []string {
+	var fields []string
+	for _, name := range DiscussionFieldNames() {
+		if name != "comments" || withComments {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// discussionSelection builds the GraphQL selection set for the requested JSON
+// fields of a discussion, followed by any extra raw selections, and returns it
+// with the fragment definitions it uses. The id is always selected because
+// follow-up queries need it.
+func discussionSelection(fields []string, extra ...string) (string, string, error) {
+	var selections, fragments []string
+	seen := make(map[string]bool)
+
+	for _, name := range append([]string{"id"}, fields...) {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		field, ok := discussionFields[name]
+		if !ok {
+			return "", "", newError(ErrInvalidInput, "unknown discussion field %q", name)
+		}
+		selections = append(selections, field.selection)
+
+		for _, fragment := range field.fragments {
+			if !seen[fragment] {
+				seen[fragment] = true
+				fragments = append(fragments, fragment)
+			}
+		}
+	}
+
+	selections = append(selections, extra...)
+	return strings.Join(selections, "\n"), strings.Join(fragments, ""), nil
+}
+
+// hasField reports whether fields contains name
+func hasField(fields []string, name string) bool {
+	for _, field := range fields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// listSelection builds the selection for each discussion in a list: the
+// requested fields, or the fields of the list table when none are requested
+func listSelection(fields []string, extra ...string) (string, string, error) {
+	if len(fields) == 0 {
+		return discussionSelection(defaultListFields, append([]string{commentCountSelection}, extra...)...)
+	}
+	return discussionSelection(fields, extra...)
+}
+
+// ValidateFieldPath checks that a dotted field path such as author.login or
+// comments.author.login is fetched by the selection of its top-level field.
+// Fields that are missing from a connection are looked up in its nodes, so
+// comments.author.login refers to the author of every comment.
+func ValidateFieldPath(path string) error {
+	segments := strings.Split(path, ".")
+
+	field, ok := discussionFields[segments[0]]
+	if !ok {
+		return fmt.Errorf("discussion has no field %q", segments[0])
+	}
+
+	fields := field.subfields
+	for i, name := range segments[1:] {
+		parent := strings.Join(segments[:i+1], ".")
+		if fields == nil {
+			return fmt.Errorf("%s has no fields", parent)
+		}
+
+		next, ok := fields[name]
+		if !ok {
+			if nodes := fields["nodes"]; nodes != nil {
+				next, ok = nodes[name]
+			}
+		}
+		if !ok {
+			return fmt.Errorf("%s has no field %q. Available fields: %s",
+				parent, name, strings.Join(fields.names(), ", "))
+		}
+		fields = next
+	}
+	return nil
+}
+
+// names returns the sorted field names, including the fields of the nodes of
+// a connection
+func (s subfields) names() []string {
+	var names []string
+	for name := range s {
+		names = append(names, name)
+	}
+	for name := range s["nodes"] {
+		if _, exists := s[name]; !exists {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/harakeishi/gh-discussion/pkg/models"
+)
+
+// jsonFieldNames returns the JSON names of the fields of a struct type
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestDiscussionFieldsMatchModel(t *testing.T) {
+	model := jsonFieldNames(reflect.TypeOf(models.Discussion{}))
+	registry := DiscussionFieldNames()
+
+	if !reflect.DeepEqual(registry, model) {
+		t.Errorf("discussionFields and models.Discussion are out of sync\nregistry: %v\nmodel:    %v", registry, model)
+	}
+}
+
+func TestDiscussionSelection(t *testing.T) {
+	selection, fragments, err := discussionSelection([]string{"title", "comments", "title"})
+	if err != nil {
+		t.Fatalf("discussionSelection() error = %v", err)
+	}
+	if !strings.HasPrefix(selection, "id\ntitle\ncomments(") || strings.Count(selection, "title") != 1 {
+		t.Errorf("selection = %q, want id, title, and comments once each", selection)
+	}
+	if !strings.Contains(fragments, "fragment commentFields") || !strings.Contains(fragments, "fragment replyFields") {
+		t.Errorf("fragments = %q, want the comment and reply fragments", fragments)
+	}
+
+	if _, _, err := discussionSelection([]string{"nope"}); err == nil {
+		t.Error("discussionSelection() accepted an unknown field")
+	}
+}
+
+func TestSubfieldsAreSelected(t *testing.T) {
+	var check func(path string, fields subfields, text string)
+	check = func(path string, fields subfields, text string) {
+		for name, sub := range fields {
+			if !regexp.MustCompile(`\b` + name + `\b`).MatchString(text) {
+				t.Errorf("%s.%s is declared but not selected", path, name)
+			}
+			check(path+"."+name, sub, text)
+		}
+	}
+
+	for name, field := range discussionFields {
+		text := field.selection + strings.Join(field.fragments, "")
+		if strings.Contains(field.selection, "{") != (field.subfields != nil) {
+			t.Errorf("%s: an object selection must declare its subfields, and only then", name)
+		}
+		check(name, field.subfields, text)
+	}
+}
+
+func TestValidateFieldPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr string
+	}{
+		{path: "title"},
+		{path: "author.login"},
+		{path: "category.slug"},
+		{path: "repository.owner.login"},
+		{path: "answer.author.name"},
+		{path: "labels.name"},
+		{path: "labels.nodes.color"},
+		{path: "comments.totalCount"},
+		{path: "comments.author.login"},
+		{path: "comments.nodes.upvoteCount"},
+		{path: "comments.replies.body"},
+		{path: "comments.replies.replyTo.url"},
+		{path: "reactionGroups.users.totalCount"},
+		{path: "nope", wantErr: `discussion has no field "nope"`},
+		{path: "title.length", wantErr: "title has no fields"},
+		{path: "author.id", wantErr: `author has no field "id"`},
+		{path: "comments.discussion.title", wantErr: `comments has no field "discussion"`},
+		{path: "answer.replies", wantErr: `answer has no field "replies"`},
+		{path: "repository.hasDiscussionsEnabled", wantErr: `repository has no field "hasDiscussionsEnabled"`},
+		{path: "comments.replies.replies", wantErr: `comments.replies has no field "replies"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			err := ValidateFieldPath(tt.path)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateFieldPath() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateFieldPath() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	err := ValidateFieldPath("author.id")
+	if err == nil || !strings.Contains(err.Error(), "Available fields: avatarUrl, email, login, name, url") {
+		t.Errorf("ValidateFieldPath() error = %v, want the available author fields", err)
+	}
+}
@@ -0,0 +1,227 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Environment variables that enable the fixture modes of the API transport
+const (
+	// RecordEnv names a directory where every API request/response pair is saved
+	RecordEnv = "GH_DISCUSSION_RECORD"
+	// ReplayEnv names a directory of saved fixtures served instead of the network
+	ReplayEnv = "GH_DISCUSSION_REPLAY"
+)
+
+// fixture is the on-disk form of a recorded API request/response pair
+type fixture struct {
+	Request  fixtureRequest  `json:"request"`
+	Response fixtureResponse `json:"response"`
+}
+
+// fixtureRequest is the part of a request that identifies a fixture: the
+// query and variables of GraphQL requests, or the method, path, and body of
+// REST requests
+type fixtureRequest struct {
+	Query     string                 `json:"query,omitempty"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+	Method    string                 `json:"method,omitempty"`
+	Path      string                 `json:"path,omitempty"`
+	Body      json.RawMessage        `json:"body,omitempty"`
+}
+
+// fixtureResponse is a recorded HTTP response
+type fixtureResponse struct {
+	StatusCode int             `json:"statusCode"`
+	Header     http.Header     `json:"header,omitempty"`
+	Body       json.RawMessage `json:"body"`
+}
+
+// fixtureTransport returns the transport selected by GH_DISCUSSION_RECORD or
+// GH_DISCUSSION_REPLAY, or nil when neither is set
+func fixtureTransport() (http.RoundTripper, error) {
+	recordDir := os.Getenv(RecordEnv)
+	replayDir := os.Getenv(ReplayEnv)
+
+	switch {
+	case recordDir != "" && replayDir != "":
+		return nil, fmt.Errorf("%s and %s cannot be used together", RecordEnv, ReplayEnv)
+	case recordDir != "":
+		if err := os.MkdirAll(recordDir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create fixture directory: %w", err)
+		}
+		return &recordTransport{dir: recordDir, next: http.DefaultTransport}, nil
+	case replayDir != "":
+		return &replayTransport{dir: replayDir}, nil
+	}
+	return nil, nil
+}
+
+// fixtureKey reads a request body and returns the identifying parts of the
+// request along with the fixture key derived from a hash of them. GraphQL
+// requests are keyed by their query and variables.
+func fixtureKey(req *http.Request) (fixtureRequest, string, error) {
+	var body fixtureRequest
+
+	var data []byte
+	if req.Body != nil {
+		var err error
+		data, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return body, "", err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	if strings.HasSuffix(req.URL.Path, "/graphql") {
+		if err := json.Unmarshal(data, &body); err != nil {
+			return body, "", fmt.Errorf("failed to parse GraphQL request: %w", err)
+		}
+	} else {
+		body.Method = req.Method
+		body.Path = req.URL.RequestURI()
+		if len(data) > 0 {
+			if !json.Valid(data) {
+				return body, "", fmt.Errorf("request to %s has a non-JSON body", req.URL)
+			}
+			body.Body = data
+		}
+	}
+
+	// Re-encode so that whitespace and key order do not change the key
+	canonical, err := json.Marshal(body)
+	if err != nil {
+		return body, "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return body, hex.EncodeToString(sum[:]), nil
+}
+
+// fixturePath returns the file that stores the fixture with the given key
+func fixturePath(dir, key string) string {
+	return filepath.Join(dir, key+".json")
+}
+
+// recordTransport sends requests over the network and saves each
+// request/response pair as a fixture
+type recordTransport struct {
+	dir  string
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	request, key, err := fixtureKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	body := json.RawMessage(data)
+	if !json.Valid(data) {
+		// Keep non-JSON error pages readable by storing them as a string
+		body, _ = json.Marshal(string(data))
+	}
+
+	f := fixture{
+		Request: request,
+		Response: fixtureResponse{
+			StatusCode: resp.StatusCode,
+			Header:     recordedHeader(resp.Header),
+			Body:       body,
+		},
+	}
+
+	out, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(fixturePath(t.dir, key), append(out, '\n'), 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write fixture: %w", err)
+	}
+
+	return resp, nil
+}
+
+// recordedHeader keeps the response headers that affect how responses are
+// handled, leaving out request-specific noise such as dates and request IDs
+func recordedHeader(h http.Header) http.Header {
+	kept := http.Header{}
+	for name, values := range h {
+		if name == "Content-Type" || strings.HasPrefix(name, "X-Ratelimit-") || name == "Retry-After" {
+			kept[name] = values
+		}
+	}
+	return kept
+}
+
+// replayTransport serves responses from previously recorded fixtures and
+// never touches the network
+type replayTransport struct {
+	dir string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	_, key, err := fixtureKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fixturePath(t.dir, key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no recorded fixture for this request (expected %s)", path)
+		}
+		return nil, err
+	}
+
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+
+	body := []byte(f.Response.Body)
+	var text string
+	if json.Unmarshal(body, &text) == nil {
+		body = []byte(text)
+	}
+
+	header := f.Response.Header
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.Response.StatusCode, http.StatusText(f.Response.StatusCode)),
+		StatusCode:    f.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
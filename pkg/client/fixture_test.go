@@ -0,0 +1,226 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+
+	"github.com/harakeishi/gh-discussion/pkg/models"
+)
+
+// stubTransport answers GraphQL requests with the response registered for
+// the operation name of their query, and counts the requests it serves
+type stubTransport struct {
+	responses map[string]string
+	calls     int
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls++
+
+	var body struct {
+		Query string `json:"query"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	req.Body.Close()
+
+	for operation, response := range t.responses {
+		if strings.Contains(body.Query, "query "+operation+"(") {
+			return stubResponse(req, http.StatusOK, response), nil
+		}
+	}
+	return stubResponse(req, http.StatusNotFound, `{"message":"Not Found"}`), nil
+}
+
+// stubResponse returns a JSON response with a Date header, which fixtures
+// must not record
+func stubResponse(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}, "Date": []string{"Mon, 01 Jan 2024 00:00:00 GMT"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
+
+// newTestClient creates a GitHubClient that sends its requests through transport
+func newTestClient(t *testing.T, transport http.RoundTripper) *GitHubClient {
+	t.Helper()
+
+	opts := api.ClientOptions{Host: "github.com", AuthToken: "test", Transport: transport}
+	gql, err := api.NewGraphQLClient(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rest, err := api.NewRESTClient(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &GitHubClient{client: gql, rest: rest}
+}
+
+const (
+	listResponse = `{"data":{"repository":{"hasDiscussionsEnabled":true,"discussions":{
+		"pageInfo":{"hasNextPage":false,"endCursor":"Y3Vyc29yOjE="},
+		"nodes":[
+			{"number":2,"title":"Second","author":{"login":"bob"}},
+			{"number":1,"title":"First","author":{"login":"alice"}}
+		]}}}}`
+	viewResponse = `{"data":{"repository":{"hasDiscussionsEnabled":true,"discussion":{
+		"id":"D_1","number":1,"title":"First","body":"Hello",
+		"author":{"login":"alice"},"category":{"name":"Q&A","isAnswerable":true}
+	}}}}`
+)
+
+func TestFixtureRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	stub := &stubTransport{responses: map[string]string{
+		"ListDiscussions": listResponse,
+		"GetDiscussion":   viewResponse,
+	}}
+
+	listOpts := models.ListOptions{Owner: "octo", Repo: "hello", State: "open", Limit: 10, Fields: []string{"number", "title", "author"}}
+	viewOpts := models.ViewOptions{Owner: "octo", Repo: "hello", Number: 1, Fields: []string{"title", "body", "author", "category"}}
+
+	ctx := context.Background()
+	recording := newTestClient(t, &recordTransport{dir: dir, next: stub})
+
+	recordedList, err := recording.ListDiscussionsWithContext(ctx, listOpts)
+	if err != nil {
+		t.Fatalf("recording ListDiscussionsWithContext() error = %v", err)
+	}
+	recordedView, err := recording.GetDiscussionWithContext(ctx, viewOpts)
+	if err != nil {
+		t.Fatalf("recording GetDiscussionWithContext() error = %v", err)
+	}
+	if stub.calls != 2 {
+		t.Fatalf("recording made %d requests, want 2", stub.calls)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("recorded %d fixtures, want 2", len(files))
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Contains(data, []byte("Date")) {
+			t.Errorf("fixture %s records the Date header:\n%s", file, data)
+		}
+	}
+
+	replaying := newTestClient(t, &replayTransport{dir: dir})
+
+	replayedList, err := replaying.ListDiscussionsWithContext(ctx, listOpts)
+	if err != nil {
+		t.Fatalf("replaying ListDiscussionsWithContext() error = %v", err)
+	}
+	replayedView, err := replaying.GetDiscussionWithContext(ctx, viewOpts)
+	if err != nil {
+		t.Fatalf("replaying GetDiscussionWithContext() error = %v", err)
+	}
+	if stub.calls != 2 {
+		t.Errorf("replaying made %d network requests, want none", stub.calls-2)
+	}
+
+	if !reflect.DeepEqual(replayedList, recordedList) {
+		t.Errorf("replayed list = %+v, want %+v", replayedList, recordedList)
+	}
+	if !reflect.DeepEqual(replayedView, recordedView) {
+		t.Errorf("replayed view = %+v, want %+v", replayedView, recordedView)
+	}
+
+	if got := len(replayedList.Nodes); got != 2 || replayedList.Nodes[0].Title != "Second" || replayedList.Nodes[1].Author.Login != "alice" {
+		t.Errorf("replayed list = %+v, want discussions Second and First", replayedList.Nodes)
+	}
+	if replayedView.Body != "Hello" || replayedView.Category.Name != "Q&A" {
+		t.Errorf("replayed view = %+v, want body Hello in Q&A", replayedView)
+	}
+}
+
+func TestFixtureKeyIsStable(t *testing.T) {
+	key := func(body string) string {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodPost, "https://api.github.com/graphql", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, k, err := fixtureKey(req)
+		if err != nil {
+			t.Fatalf("fixtureKey(%s) error = %v", body, err)
+		}
+
+		// The body must still be readable by the next transport
+		rest, err := io.ReadAll(req.Body)
+		if err != nil || string(rest) != body {
+			t.Errorf("request body after fixtureKey = %q, want %q", rest, body)
+		}
+		return k
+	}
+
+	base := key(`{"query":"query { viewer { login } }","variables":{"a":1,"b":"x"}}`)
+
+	if got := key(`{ "variables": {"b": "x", "a": 1}, "query": "query { viewer { login } }" }`); got != base {
+		t.Errorf("key changed with whitespace and key order: %s != %s", got, base)
+	}
+	if got := key(`{"query":"query { viewer { login } }","variables":{"a":2,"b":"x"}}`); got == base {
+		t.Error("key did not change with the variables")
+	}
+	if got := key(`{"query":"query { viewer { name } }","variables":{"a":1,"b":"x"}}`); got == base {
+		t.Error("key did not change with the query")
+	}
+
+	rest := func(method, url, body string) string {
+		t.Helper()
+		req, err := http.NewRequest(method, url, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, k, err := fixtureKey(req)
+		if err != nil {
+			t.Fatalf("fixtureKey(%s %s) error = %v", method, url, err)
+		}
+		return k
+	}
+
+	patch := rest(http.MethodPatch, "https://api.github.com/repos/octo/hello", `{"has_discussions":true}`)
+	if got := rest(http.MethodPatch, "https://api.github.com/repos/octo/hello", `{ "has_discussions": true }`); got != patch {
+		t.Errorf("REST key changed with whitespace: %s != %s", got, patch)
+	}
+	if got := rest(http.MethodPatch, "https://api.github.com/repos/octo/hello", `{"has_discussions":false}`); got == patch {
+		t.Error("REST key did not change with the body")
+	}
+	if got := rest(http.MethodPatch, "https://api.github.com/repos/octo/other", `{"has_discussions":true}`); got == patch {
+		t.Error("REST key did not change with the path")
+	}
+}
+
+func TestReplayMissingFixture(t *testing.T) {
+	dir := t.TempDir()
+	c := newTestClient(t, &replayTransport{dir: dir})
+
+	_, err := c.GetDiscussionWithContext(context.Background(), models.ViewOptions{Owner: "octo", Repo: "hello", Number: 1})
+	if err == nil {
+		t.Fatal("GetDiscussionWithContext() succeeded without a fixture")
+	}
+	if !strings.Contains(err.Error(), "no recorded fixture for this request") || !strings.Contains(err.Error(), dir) {
+		t.Errorf("error = %v, want it to name the missing fixture in %s", err, dir)
+	}
+}
@@ -1,79 +1,212 @@
 package client
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/auth"
 	"github.com/harakeishi/gh-discussion/pkg/models"
 )
 
 // GitHubClient wraps the GitHub GraphQL API client
 type GitHubClient struct {
 	client *api.GraphQLClient
+	// rest is used for the few operations that have no GraphQL equivalent
+	rest *api.RESTClient
+	// rateLimitMu guards rateLimit, since a client may be shared by goroutines
+	rateLimitMu sync.Mutex
+	// rateLimit is the rate limit status reported by the most recent query
+	rateLimit *models.RateLimit
+	// timeout limits each API request; zero means no limit
+	timeout time.Duration
+	// notices receives messages about rate limit waits; nil discards them
+	notices io.Writer
 }
 
-// NewGitHubClient creates a new GitHub client
+// Options configures a GitHubClient
+type Options struct {
+	// Host is a github.com or GitHub Enterprise Server host. An empty host
+	// selects the default host.
+	Host string
+	// Timeout limits how long each API request, including each page of a
+	// list, may take. Zero means no limit.
+	Timeout time.Duration
+	// Notices receives messages about waiting for and retrying after rate
+	// limits. Nil discards them.
+	Notices io.Writer
+}
+
+// NewGitHubClient creates a new GitHub client for the default host, which is
+// taken from GH_HOST or the GitHub CLI configuration
 func NewGitHubClient() (*GitHubClient, error) {
-	client, err := api.DefaultGraphQLClient()
+	return NewGitHubClientForHost("")
+}
+
+// NewGitHubClientForHost creates a new GitHub client for a github.com or
+// GitHub Enterprise Server host. An empty host selects the default host.
+func NewGitHubClientForHost(host string) (*GitHubClient, error) {
+	return NewGitHubClientWithOptions(Options{Host: host})
+}
+
+// NewGitHubClientWithOptions creates a new GitHub client configured by opts.
+// Setting GH_DISCUSSION_RECORD or GH_DISCUSSION_REPLAY to a directory records
+// API traffic to fixtures or replays it from them.
+func NewGitHubClientWithOptions(opts Options) (*GitHubClient, error) {
+	transport, err := fixtureTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	apiOpts := api.ClientOptions{Host: opts.Host, Transport: transport}
+	if _, ok := transport.(*replayTransport); ok {
+		// Replayed requests never leave the machine, so no real token is needed
+		apiOpts.AuthToken = "replay"
+	} else {
+		// Look the token up here rather than leaving it to go-gh, whose
+		// missing-token error can only be told apart by its message
+		if apiOpts.Host == "" {
+			apiOpts.Host, _ = auth.DefaultHost()
+		}
+		apiOpts.AuthToken, _ = auth.TokenForHost(apiOpts.Host)
+		if apiOpts.AuthToken == "" {
+			return nil, newError(ErrForbidden, "authentication token not found for host %s", apiOpts.Host)
+		}
+	}
+
+	client, err := api.NewGraphQLClient(apiOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GraphQL client: %w", err)
 	}
 
+	rest, err := api.NewRESTClient(apiOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create REST client: %w", err)
+	}
+
 	return &GitHubClient{
-		client: client,
+		client:  client,
+		rest:    rest,
+		timeout: opts.Timeout,
+		notices: opts.Notices,
 	}, nil
 }
 
-// ListDiscussions retrieves a list of discussions based on the provided options
+// maxPageSize is the largest page size accepted by the GitHub GraphQL API
+const maxPageSize = 100
+
+// ListDiscussions wraps ListDiscussionsWithContext using context.Background
 func (c *GitHubClient) ListDiscussions(opts models.ListOptions) (*models.DiscussionConnection, error) {
+	return c.ListDiscussionsWithContext(context.Background(), opts)
+}
+
+// ListDiscussionsWithContext retrieves a list of discussions based on the provided options.
+// It follows pagination cursors until opts.Limit discussions have been fetched,
+// or until all discussions have been fetched when opts.Limit is 0.
+func (c *GitHubClient) ListDiscussionsWithContext(ctx context.Context, opts models.ListOptions) (*models.DiscussionConnection, error) {
+	// Resolve the category once rather than on every page. This also accepts
+	// slugs and case-insensitive names, and fails on unknown categories rather
+	// than silently listing every discussion.
+	categoryID := ""
+	if opts.Category != "" {
+		category, err := c.getCategory(ctx, opts.Owner, opts.Repo, opts.Category)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get category: %w", err)
+		}
+		categoryID = category.ID
+		opts.Category = category.Name
+	}
+
 	// Use search API if search term or author filter is specified
-	if opts.Search != "" || opts.Author != "" {
-		return c.searchDiscussions(opts)
+	search := opts.Search != "" || opts.Author != ""
+	fetch := c.searchDiscussions
+	if !search {
+		fetch = func(ctx context.Context, opts models.ListOptions) (*models.DiscussionConnection, error) {
+			return c.listRepositoryDiscussions(ctx, opts, categoryID)
+		}
+	}
+
+	// Nested comments and replies count towards the GraphQL node limit, so
+	// fetch fewer discussions per page when they are requested
+	pageSize := maxPageSize
+	if hasField(opts.Fields, "comments") {
+		pageSize = commentsPageSize
+	}
+
+	// Start from an empty slice so that no matches encode as [] rather than null
+	result := &models.DiscussionConnection{Nodes: []models.Discussion{}}
+	pageOpts := opts
+	for {
+		pageOpts.Limit = pageSize
+		if opts.Limit > 0 && opts.Limit-len(result.Nodes) < pageSize {
+			pageOpts.Limit = opts.Limit - len(result.Nodes)
+		}
+
+		page, err := fetch(ctx, pageOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Nodes = append(result.Nodes, page.Nodes...)
+		result.PageInfo = page.PageInfo
+
+		if !page.PageInfo.HasNextPage || (opts.Limit > 0 && len(result.Nodes) >= opts.Limit) {
+			break
+		}
+		pageOpts.After = page.PageInfo.EndCursor
+	}
+
+	// Search returns no results rather than an error when discussions are
+	// disabled, so tell the two apart
+	if search && len(result.Nodes) == 0 && opts.Owner != "" && opts.Repo != "" {
+		repo, err := c.GetRepositoryInfoWithContext(ctx, opts.Owner, opts.Repo)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkDiscussionsEnabled(&repo.HasDiscussionsEnabled, opts.Owner, opts.Repo); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range result.Nodes {
+		if result.Nodes[i].Comments != nil {
+			if err := c.fetchRemainingComments(ctx, &result.Nodes[i]); err != nil {
+				return nil, err
+			}
+		}
 	}
-	return c.listRepositoryDiscussions(opts)
+
+	return result, nil
 }
 
-// listRepositoryDiscussions lists discussions in a specific repository
-func (c *GitHubClient) listRepositoryDiscussions(opts models.ListOptions) (*models.DiscussionConnection, error) {
+// listRepositoryDiscussions lists a single page of discussions in a specific repository
+func (c *GitHubClient) listRepositoryDiscussions(ctx context.Context, opts models.ListOptions, categoryID string) (*models.DiscussionConnection, error) {
+	selection, fragments, err := listSelection(opts.Fields)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		query ListDiscussions($owner: String!, $repo: String!, $first: Int!, $after: String, $orderBy: DiscussionOrder, $categoryId: ID, $answered: Boolean) {
+		query ListDiscussions($owner: String!, $repo: String!, $first: Int!, $after: String, $orderBy: DiscussionOrder, $categoryId: ID, $answered: Boolean, $states: [DiscussionState!]) {
 			repository(owner: $owner, name: $repo) {
-				discussions(first: $first, after: $after, orderBy: $orderBy, categoryId: $categoryId, answered: $answered) {
+				hasDiscussionsEnabled
+				discussions(first: $first, after: $after, orderBy: $orderBy, categoryId: $categoryId, answered: $answered, states: $states) {
 					pageInfo {
 						hasNextPage
 						endCursor
 					}
 					nodes {
-						id
-						number
-						title
-						bodyText
-						createdAt
-						updatedAt
-						author {
-							login
-							url
-						}
-						category {
-							name
-						}
-						url
-						answerChosenAt
-						isAnswered
-						comments(first: 0) {
-							totalCount
-						}
-						labels(first: 10) {
-							nodes {
-								name
-								color
-							}
-						}
+						` + selection + `
 					}
 				}
 			}
-		}`
+		}` + fragments
 
 	variables := map[string]interface{}{
 		"owner": opts.Owner,
@@ -96,24 +229,30 @@ func (c *GitHubClient) listRepositoryDiscussions(opts models.ListOptions) (*mode
 		variables["answered"] = *opts.Answered
 	}
 
+	// Add state filter if specified
+	switch opts.State {
+	case "open":
+		variables["states"] = []string{"OPEN"}
+	case "closed":
+		variables["states"] = []string{"CLOSED"}
+	}
+
 	// Add category filter if specified
-	if opts.Category != "" {
-		categoryID, err := c.getCategoryID(opts.Owner, opts.Repo, opts.Category)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get category ID: %w", err)
-		}
-		if categoryID != "" {
-			variables["categoryId"] = categoryID
-		}
+	if categoryID != "" {
+		variables["categoryId"] = categoryID
 	}
 
 	var response struct {
 		Repository struct {
-			Discussions models.DiscussionConnection `json:"discussions"`
+			HasDiscussionsEnabled *bool                       `json:"hasDiscussionsEnabled"`
+			Discussions           models.DiscussionConnection `json:"discussions"`
 		} `json:"repository"`
 	}
 
-	err := c.client.Do(query, variables, &response)
+	err = c.do(ctx, query, variables, &response)
+	if err := checkDiscussionsEnabled(response.Repository.HasDiscussionsEnabled, opts.Owner, opts.Repo); err != nil {
+		return nil, err
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to list discussions: %w", err)
 	}
@@ -121,8 +260,14 @@ func (c *GitHubClient) listRepositoryDiscussions(opts models.ListOptions) (*mode
 	return &response.Repository.Discussions, nil
 }
 
-// searchDiscussions searches for discussions using GitHub's search API
-func (c *GitHubClient) searchDiscussions(opts models.ListOptions) (*models.DiscussionConnection, error) {
+// searchDiscussions searches a single page of discussions using GitHub's search API
+func (c *GitHubClient) searchDiscussions(ctx context.Context, opts models.ListOptions) (*models.DiscussionConnection, error) {
+	// Search results can come from any repository, so always identify it
+	selection, fragments, err := listSelection(opts.Fields, discussionFields["repository"].selection)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		query SearchDiscussions($query: String!, $first: Int!, $after: String) {
 			search(type: DISCUSSION, query: $query, first: $first, after: $after) {
@@ -132,38 +277,11 @@ func (c *GitHubClient) searchDiscussions(opts models.ListOptions) (*models.Discu
 				}
 				nodes {
 					... on Discussion {
-						id
-						number
-						title
-						bodyText
-						createdAt
-						updatedAt
-						author {
-							login
-							url
-						}
-						category {
-							name
-						}
-						repository {
-							nameWithOwner
-						}
-						url
-						answerChosenAt
-						isAnswered
-						comments(first: 0) {
-							totalCount
-						}
-						labels(first: 10) {
-							nodes {
-								name
-								color
-							}
-						}
+						` + selection + `
 					}
 				}
 			}
-		}`
+		}` + fragments
 
 	searchQuery := c.buildSearchQuery(opts)
 
@@ -180,7 +298,7 @@ func (c *GitHubClient) searchDiscussions(opts models.ListOptions) (*models.Discu
 		Search models.SearchResult `json:"search"`
 	}
 
-	err := c.client.Do(query, variables, &response)
+	err = c.do(ctx, query, variables, &response)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search discussions: %w", err)
 	}
@@ -224,6 +342,14 @@ func (c *GitHubClient) buildSearchQuery(opts models.ListOptions) string {
 		}
 	}
 
+	// Add state filter
+	switch opts.State {
+	case "open":
+		parts = append(parts, "is:open")
+	case "closed":
+		parts = append(parts, "is:closed")
+	}
+
 	// Add label filters
 	for _, label := range opts.Labels {
 		parts = append(parts, fmt.Sprintf("label:\"%s\"", label))
@@ -232,195 +358,249 @@ func (c *GitHubClient) buildSearchQuery(opts models.ListOptions) string {
 	return strings.Join(parts, " ")
 }
 
-// GetDiscussion retrieves a specific discussion by number
+// GetDiscussion wraps GetDiscussionWithContext using context.Background
 func (c *GitHubClient) GetDiscussion(opts models.ViewOptions) (*models.Discussion, error) {
+	return c.GetDiscussionWithContext(context.Background(), opts)
+}
+
+// GetDiscussionWithContext retrieves a specific discussion by number
+func (c *GitHubClient) GetDiscussionWithContext(ctx context.Context, opts models.ViewOptions) (*models.Discussion, error) {
+	fields := opts.Fields
+	if len(fields) == 0 {
+		fields = defaultViewFields(opts.ShowComments)
+	} else if opts.ShowComments {
+		fields = append(fields, "comments")
+	}
+
+	selection, fragments, err := discussionSelection(fields)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		query GetDiscussion($owner: String!, $repo: String!, $number: Int!, $includeComments: Boolean!) {
+		query GetDiscussion($owner: String!, $repo: String!, $number: Int!) {
 			repository(owner: $owner, name: $repo) {
+				hasDiscussionsEnabled
 				discussion(number: $number) {
-					id
-					number
-					title
-					body
-					bodyText
-					bodyHTML
-					createdAt
-					updatedAt
-					publishedAt
-					lastEditedAt
-					author {
-						login
-						url
-						avatarUrl
-						... on User {
-							name
-							email
+					` + selection + `
+				}
+			}
+		}` + fragments
+
+	variables := map[string]interface{}{
+		"owner":  opts.Owner,
+		"repo":   opts.Repo,
+		"number": opts.Number,
+	}
+
+	var response struct {
+		Repository struct {
+			HasDiscussionsEnabled *bool              `json:"hasDiscussionsEnabled"`
+			Discussion            *models.Discussion `json:"discussion"`
+		} `json:"repository"`
+	}
+
+	err = c.do(ctx, query, variables, &response)
+	if err := checkDiscussionsEnabled(response.Repository.HasDiscussionsEnabled, opts.Owner, opts.Repo); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get discussion: %w", err)
+	}
+
+	if response.Repository.Discussion == nil {
+		return nil, newError(ErrNotFound, "discussion #%d not found", opts.Number)
+	}
+
+	discussion := response.Repository.Discussion
+	if discussion.Comments != nil {
+		if err := c.fetchRemainingComments(ctx, discussion); err != nil {
+			return nil, err
+		}
+	}
+
+	return discussion, nil
+}
+
+// commentFragment selects the fields of a top-level discussion comment,
+// including the first page of its replies
+const commentFragment = `
+		fragment commentFields on DiscussionComment {
+			id
+			databaseId
+			body
+			bodyText
+			bodyHTML
+			createdAt
+			updatedAt
+			publishedAt
+			author {
+				login
+				url
+				avatarUrl
+				... on User {
+					name
+					email
+				}
+			}
+			authorAssociation
+			upvoteCount
+			isAnswer
+			isMinimized
+			minimizedReason
+			reactionGroups {
+				content
+				users {
+					totalCount
+				}
+			}
+			url
+			viewerCanMarkAsAnswer
+			viewerCanUnmarkAsAnswer
+			replies(first: 100) {
+				totalCount
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+				nodes {
+					...replyFields
+				}
+			}
+		}`
+
+// replyFragment selects the fields of a reply to a discussion comment
+const replyFragment = `
+		fragment replyFields on DiscussionComment {
+			id
+			databaseId
+			body
+			bodyText
+			createdAt
+			updatedAt
+			author {
+				login
+				url
+				avatarUrl
+				... on User {
+					name
+					email
+				}
+			}
+			authorAssociation
+			isAnswer
+			url
+			replyTo {
+				id
+				url
+			}
+		}`
+
+// fetchRemainingComments follows the comment and reply cursors of a discussion
+// fetched with its comments and merges the remaining pages into it
+func (c *GitHubClient) fetchRemainingComments(ctx context.Context, discussion *models.Discussion) error {
+	query := `
+		query GetDiscussionComments($id: ID!, $after: String) {
+			node(id: $id) {
+				... on Discussion {
+					comments(first: 100, after: $after) {
+						totalCount
+						pageInfo {
+							hasNextPage
+							endCursor
 						}
-					}
-					category {
-						name
-						description
-						emoji
-						emojiHTML
-						isAnswerable
-					}
-					repository {
-						nameWithOwner
-						url
-						description
-					}
-					labels(first: 10) {
 						nodes {
-							name
-							color
-						}
-					}
-					url
-					resourcePath
-					locked
-					activeLockReason
-					answerChosenAt
-					answerChosenBy {
-						login
-						url
-						... on User {
-							name
-							email
-						}
-					}
-					answer {
-						id
-						body
-						bodyText
-						createdAt
-						author {
-							login
-							url
-							... on User {
-								name
-								email
-							}
-						}
-						isAnswer
-					}
-					isAnswered
-					upvoteCount
-					reactionGroups {
-						content
-						users {
-							totalCount
-						}
-					}
-					viewerCanDelete
-					viewerCanReact
-					viewerCanSubscribe
-					viewerCanUpdate
-					viewerDidAuthor
-					viewerSubscription
-					authorAssociation
-					createdViaEmail
-					databaseId
-					editor {
-						login
-						url
-						... on User {
-							name
-							email
+							...commentFields
 						}
 					}
-					includesCreatedEdit
-					comments(first: 100) @include(if: $includeComments) {
+				}
+			}
+		}` + commentFragment + replyFragment
+
+	comments := discussion.Comments
+	for comments.PageInfo.HasNextPage {
+		variables := map[string]interface{}{
+			"id":    discussion.ID,
+			"after": comments.PageInfo.EndCursor,
+		}
+
+		var response struct {
+			Node struct {
+				Comments models.CommentConnection `json:"comments"`
+			} `json:"node"`
+		}
+
+		err := c.do(ctx, query, variables, &response)
+		if err != nil {
+			return fmt.Errorf("failed to get discussion comments: %w", err)
+		}
+
+		comments.Nodes = append(comments.Nodes, response.Node.Comments.Nodes...)
+		comments.PageInfo = response.Node.Comments.PageInfo
+	}
+
+	for i := range comments.Nodes {
+		if err := c.fetchRemainingReplies(ctx, &comments.Nodes[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetchRemainingReplies follows the reply cursor of a comment and merges the
+// remaining pages into it
+func (c *GitHubClient) fetchRemainingReplies(ctx context.Context, comment *models.Comment) error {
+	query := `
+		query GetCommentReplies($id: ID!, $after: String) {
+			node(id: $id) {
+				... on DiscussionComment {
+					replies(first: 100, after: $after) {
 						totalCount
 						pageInfo {
 							hasNextPage
 							endCursor
 						}
 						nodes {
-							id
-							body
-							bodyText
-							bodyHTML
-							createdAt
-							updatedAt
-							publishedAt
-							author {
-								login
-								url
-								avatarUrl
-								... on User {
-									name
-									email
-								}
-							}
-							authorAssociation
-							upvoteCount
-							isAnswer
-							isMinimized
-							minimizedReason
-							reactionGroups {
-								content
-								users {
-									totalCount
-								}
-							}
-							url
-							viewerCanMarkAsAnswer
-							viewerCanUnmarkAsAnswer
-							replies(first: 50) {
-								totalCount
-								nodes {
-									id
-									body
-									bodyText
-									createdAt
-									updatedAt
-									author {
-										login
-										url
-										avatarUrl
-										... on User {
-											name
-											email
-										}
-									}
-									authorAssociation
-									isAnswer
-									url
-								}
-							}
+							...replyFields
 						}
 					}
 				}
 			}
-		}`
+		}` + replyFragment
 
-	variables := map[string]interface{}{
-		"owner":           opts.Owner,
-		"repo":            opts.Repo,
-		"number":          opts.Number,
-		"includeComments": opts.ShowComments,
-	}
+	replies := comment.Replies
+	for replies != nil && replies.PageInfo.HasNextPage {
+		variables := map[string]interface{}{
+			"id":    comment.ID,
+			"after": replies.PageInfo.EndCursor,
+		}
 
-	var response struct {
-		Repository struct {
-			Discussion *models.Discussion `json:"discussion"`
-		} `json:"repository"`
-	}
+		var response struct {
+			Node struct {
+				Replies models.CommentConnection `json:"replies"`
+			} `json:"node"`
+		}
 
-	err := c.client.Do(query, variables, &response)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get discussion: %w", err)
-	}
+		err := c.do(ctx, query, variables, &response)
+		if err != nil {
+			return fmt.Errorf("failed to get comment replies: %w", err)
+		}
 
-	if response.Repository.Discussion == nil {
-		return nil, fmt.Errorf("discussion #%d not found", opts.Number)
+		replies.Nodes = append(replies.Nodes, response.Node.Replies.Nodes...)
+		replies.PageInfo = response.Node.Replies.PageInfo
 	}
 
-	return response.Repository.Discussion, nil
+	return nil
 }
 
-// GetRepositoryInfo retrieves basic repository information
+// GetRepositoryInfo wraps GetRepositoryInfoWithContext using context.Background
 func (c *GitHubClient) GetRepositoryInfo(owner, repo string) (*models.Repository, error) {
+	return c.GetRepositoryInfoWithContext(context.Background(), owner, repo)
+}
+
+// GetRepositoryInfoWithContext retrieves basic repository information
+func (c *GitHubClient) GetRepositoryInfoWithContext(ctx context.Context, owner, repo string) (*models.Repository, error) {
 	query := `
 		query GetRepository($owner: String!, $repo: String!) {
 			repository(owner: $owner, name: $repo) {
@@ -433,6 +613,7 @@ func (c *GitHubClient) GetRepositoryInfo(owner, repo string) (*models.Repository
 				}
 				url
 				description
+				hasDiscussionsEnabled
 			}
 		}`
 
@@ -445,20 +626,25 @@ func (c *GitHubClient) GetRepositoryInfo(owner, repo string) (*models.Repository
 		Repository *models.Repository `json:"repository"`
 	}
 
-	err := c.client.Do(query, variables, &response)
+	err := c.do(ctx, query, variables, &response)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get repository info: %w", err)
 	}
 
 	if response.Repository == nil {
-		return nil, fmt.Errorf("repository %s/%s not found", owner, repo)
+		return nil, newError(ErrNotFound, "repository %s/%s not found", owner, repo)
 	}
 
 	return response.Repository, nil
 }
 
-// GetDiscussionCategories retrieves available discussion categories for a repository
+// GetDiscussionCategories wraps GetDiscussionCategoriesWithContext using context.Background
 func (c *GitHubClient) GetDiscussionCategories(owner, repo string) ([]models.Category, error) {
+	return c.GetDiscussionCategoriesWithContext(context.Background(), owner, repo)
+}
+
+// GetDiscussionCategoriesWithContext retrieves available discussion categories for a repository
+func (c *GitHubClient) GetDiscussionCategoriesWithContext(ctx context.Context, owner, repo string) ([]models.Category, error) {
 	query := `
 		query GetDiscussionCategories($owner: String!, $repo: String!) {
 			repository(owner: $owner, name: $repo) {
@@ -466,6 +652,7 @@ func (c *GitHubClient) GetDiscussionCategories(owner, repo string) ([]models.Cat
 					nodes {
 						id
 						name
+						slug
 						description
 						emoji
 						emojiHTML
@@ -490,7 +677,7 @@ func (c *GitHubClient) GetDiscussionCategories(owner, repo string) ([]models.Cat
 		} `json:"repository"`
 	}
 
-	err := c.client.Do(query, variables, &response)
+	err := c.do(ctx, query, variables, &response)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get discussion categories: %w", err)
 	}
@@ -498,18 +685,827 @@ func (c *GitHubClient) GetDiscussionCategories(owner, repo string) ([]models.Cat
 	return response.Repository.DiscussionCategories.Nodes, nil
 }
 
-// getCategoryID retrieves the ID of a discussion category by name
-func (c *GitHubClient) getCategoryID(owner, repo, categoryName string) (string, error) {
-	categories, err := c.GetDiscussionCategories(owner, repo)
-	if err != nil {
-		return "", err
+// GetDiscussionTemplate wraps GetDiscussionTemplateWithContext using context.Background
+func (c *GitHubClient) GetDiscussionTemplate(owner, repo, categorySlug string) ([]byte, error) {
+	return c.GetDiscussionTemplateWithContext(context.Background(), owner, repo, categorySlug)
+}
+
+// GetDiscussionTemplateWithContext retrieves the discussion form template for a category
+// from .github/DISCUSSION_TEMPLATE on the default branch. It returns nil when
+// the category has no template.
+func (c *GitHubClient) GetDiscussionTemplateWithContext(ctx context.Context, owner, repo, categorySlug string) ([]byte, error) {
+	query := `
+		query GetDiscussionTemplate($owner: String!, $repo: String!, $yml: String!, $yaml: String!) {
+			repository(owner: $owner, name: $repo) {
+				yml: object(expression: $yml) {
+					... on Blob {
+						text
+					}
+				}
+				yaml: object(expression: $yaml) {
+					... on Blob {
+						text
+					}
+				}
+			}
+		}`
+
+	path := "HEAD:.github/DISCUSSION_TEMPLATE/" + categorySlug
+	variables := map[string]interface{}{
+		"owner": owner,
+		"repo":  repo,
+		"yml":   path + ".yml",
+		"yaml":  path + ".yaml",
 	}
 
-	for _, category := range categories {
-		if category.Name == categoryName {
-			return category.ID, nil
-		}
+	type blob struct {
+		Text string `json:"text"`
+	}
+
+	var response struct {
+		Repository struct {
+			YML  *blob `json:"yml"`
+			YAML *blob `json:"yaml"`
+		} `json:"repository"`
+	}
+
+	err := c.do(ctx, query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get discussion template: %w", err)
+	}
+
+	switch {
+	case response.Repository.YML != nil:
+		return []byte(response.Repository.YML.Text), nil
+	case response.Repository.YAML != nil:
+		return []byte(response.Repository.YAML.Text), nil
+	default:
+		return nil, nil
+	}
+}
+
+// CreateDiscussion wraps CreateDiscussionWithContext using context.Background
+func (c *GitHubClient) CreateDiscussion(opts models.CreateOptions) (*models.Discussion, error) {
+	return c.CreateDiscussionWithContext(context.Background(), opts)
+}
+
+// CreateDiscussionWithContext creates a new discussion in a repository
+func (c *GitHubClient) CreateDiscussionWithContext(ctx context.Context, opts models.CreateOptions) (*models.Discussion, error) {
+	query := `
+		mutation CreateDiscussion($input: CreateDiscussionInput!) {
+			createDiscussion(input: $input) {
+				discussion {
+					id
+					number
+					title
+					url
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"repositoryId": opts.RepositoryID,
+			"categoryId":   opts.CategoryID,
+			"title":        opts.Title,
+			"body":         opts.Body,
+		},
+	}
+
+	var response struct {
+		CreateDiscussion struct {
+			Discussion *models.Discussion `json:"discussion"`
+		} `json:"createDiscussion"`
+	}
+
+	err := c.do(ctx, query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discussion: %w", err)
+	}
+
+	if response.CreateDiscussion.Discussion == nil {
+		return nil, fmt.Errorf("failed to create discussion: empty response")
+	}
+
+	return response.CreateDiscussion.Discussion, nil
+}
+
+// GetDiscussionID wraps GetDiscussionIDWithContext using context.Background
+func (c *GitHubClient) GetDiscussionID(owner, repo string, number int) (string, error) {
+	return c.GetDiscussionIDWithContext(context.Background(), owner, repo, number)
+}
+
+// GetDiscussionIDWithContext retrieves the node ID of a discussion by number
+func (c *GitHubClient) GetDiscussionIDWithContext(ctx context.Context, owner, repo string, number int) (string, error) {
+	query := `
+		query GetDiscussionID($owner: String!, $repo: String!, $number: Int!) {
+			repository(owner: $owner, name: $repo) {
+				discussion(number: $number) {
+					id
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"repo":   repo,
+		"number": number,
+	}
+
+	var response struct {
+		Repository struct {
+			Discussion *struct {
+				ID string `json:"id"`
+			} `json:"discussion"`
+		} `json:"repository"`
+	}
+
+	err := c.do(ctx, query, variables, &response)
+	if err != nil {
+		return "", fmt.Errorf("failed to get discussion: %w", err)
+	}
+
+	if response.Repository.Discussion == nil {
+		return "", newError(ErrNotFound, "discussion #%d not found", number)
+	}
+
+	return response.Repository.Discussion.ID, nil
+}
+
+// FindDiscussionComment wraps FindDiscussionCommentWithContext using context.Background
+func (c *GitHubClient) FindDiscussionComment(owner, repo string, number, databaseID int) (*models.Comment, error) {
+	return c.FindDiscussionCommentWithContext(context.Background(), owner, repo, number, databaseID)
+}
+
+// FindDiscussionCommentWithContext looks up a comment or reply of a discussion by its
+// database ID, as found in discussioncomment-<id> URL fragments
+func (c *GitHubClient) FindDiscussionCommentWithContext(ctx context.Context, owner, repo string, number, databaseID int) (*models.Comment, error) {
+	query := `
+		query FindDiscussionComment($owner: String!, $repo: String!, $number: Int!, $after: String) {
+			repository(owner: $owner, name: $repo) {
+				discussion(number: $number) {
+					comments(first: 100, after: $after) {
+						pageInfo {
+							hasNextPage
+							endCursor
+						}
+						nodes {
+							id
+							databaseId
+							url
+							replies(first: 100) {
+								pageInfo {
+									hasNextPage
+									endCursor
+								}
+								nodes {
+									id
+									databaseId
+									url
+									replyTo {
+										id
+										url
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"repo":   repo,
+		"number": number,
+	}
+
+	for {
+		var response struct {
+			Repository struct {
+				Discussion *struct {
+					Comments models.CommentConnection `json:"comments"`
+				} `json:"discussion"`
+			} `json:"repository"`
+		}
+
+		err := c.do(ctx, query, variables, &response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find comment: %w", err)
+		}
+
+		if response.Repository.Discussion == nil {
+			return nil, newError(ErrNotFound, "discussion #%d not found", number)
+		}
+
+		comments := response.Repository.Discussion.Comments
+		for _, comment := range comments.Nodes {
+			if comment.DatabaseID == databaseID {
+				return &comment, nil
+			}
+			if comment.Replies == nil {
+				continue
+			}
+			if err := c.fetchRemainingReplies(ctx, &comment); err != nil {
+				return nil, err
+			}
+			for _, reply := range comment.Replies.Nodes {
+				if reply.DatabaseID == databaseID {
+					return &reply, nil
+				}
+			}
+		}
+
+		if !comments.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = comments.PageInfo.EndCursor
+	}
+
+	return nil, newError(ErrNotFound, "comment %d not found in discussion #%d", databaseID, number)
+}
+
+// AddDiscussionComment wraps AddDiscussionCommentWithContext using context.Background
+func (c *GitHubClient) AddDiscussionComment(opts models.CommentOptions) (*models.Comment, error) {
+	return c.AddDiscussionCommentWithContext(context.Background(), opts)
+}
+
+// AddDiscussionCommentWithContext adds a comment to a discussion, or a reply to an
+// existing comment thread when ReplyToID is set
+func (c *GitHubClient) AddDiscussionCommentWithContext(ctx context.Context, opts models.CommentOptions) (*models.Comment, error) {
+	query := `
+		mutation AddDiscussionComment($input: AddDiscussionCommentInput!) {
+			addDiscussionComment(input: $input) {
+				comment {
+					id
+					databaseId
+					url
+					replyTo {
+						id
+						url
+					}
+				}
+			}
+		}`
+
+	input := map[string]interface{}{
+		"discussionId": opts.DiscussionID,
+		"body":         opts.Body,
+	}
+	if opts.ReplyToID != "" {
+		input["replyToId"] = opts.ReplyToID
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response struct {
+		AddDiscussionComment struct {
+			Comment *models.Comment `json:"comment"`
+		} `json:"addDiscussionComment"`
+	}
+
+	err := c.do(ctx, query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	if response.AddDiscussionComment.Comment == nil {
+		return nil, fmt.Errorf("failed to add comment: empty response")
+	}
+
+	return response.AddDiscussionComment.Comment, nil
+}
+
+// UpdateDiscussion wraps UpdateDiscussionWithContext using context.Background
+func (c *GitHubClient) UpdateDiscussion(opts models.UpdateOptions) (*models.Discussion, error) {
+	return c.UpdateDiscussionWithContext(context.Background(), opts)
+}
+
+// UpdateDiscussionWithContext updates the title, body, or category of a discussion
+func (c *GitHubClient) UpdateDiscussionWithContext(ctx context.Context, opts models.UpdateOptions) (*models.Discussion, error) {
+	query := `
+		mutation UpdateDiscussion($input: UpdateDiscussionInput!) {
+			updateDiscussion(input: $input) {
+				discussion {
+					id
+					number
+					title
+					url
+				}
+			}
+		}`
+
+	input := map[string]interface{}{
+		"discussionId": opts.DiscussionID,
+	}
+	if opts.Title != "" {
+		input["title"] = opts.Title
+	}
+	if opts.Body != "" {
+		input["body"] = opts.Body
+	}
+	if opts.CategoryID != "" {
+		input["categoryId"] = opts.CategoryID
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response struct {
+		UpdateDiscussion struct {
+			Discussion *models.Discussion `json:"discussion"`
+		} `json:"updateDiscussion"`
+	}
+
+	err := c.do(ctx, query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update discussion: %w", err)
+	}
+
+	if response.UpdateDiscussion.Discussion == nil {
+		return nil, fmt.Errorf("failed to update discussion: empty response")
+	}
+
+	return response.UpdateDiscussion.Discussion, nil
+}
+
+// GetLabelIDs wraps GetLabelIDsWithContext using context.Background
+func (c *GitHubClient) GetLabelIDs(owner, repo string, names []string) ([]string, error) {
+	return c.GetLabelIDsWithContext(context.Background(), owner, repo, names)
+}
+
+// GetLabelIDsWithContext retrieves the node IDs of repository labels by name
+func (c *GitHubClient) GetLabelIDsWithContext(ctx context.Context, owner, repo string, names []string) ([]string, error) {
+	query := `
+		query GetLabel($owner: String!, $repo: String!, $name: String!) {
+			repository(owner: $owner, name: $repo) {
+				label(name: $name) {
+					id
+				}
+			}
+		}`
+
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		variables := map[string]interface{}{
+			"owner": owner,
+			"repo":  repo,
+			"name":  name,
+		}
+
+		var response struct {
+			Repository struct {
+				Label *struct {
+					ID string `json:"id"`
+				} `json:"label"`
+			} `json:"repository"`
+		}
+
+		err := c.do(ctx, query, variables, &response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get label %q: %w", name, err)
+		}
+
+		if response.Repository.Label == nil {
+			return nil, newError(ErrNotFound, "label %q not found in %s/%s", name, owner, repo)
+		}
+
+		ids = append(ids, response.Repository.Label.ID)
+	}
+
+	return ids, nil
+}
+
+// AddLabels wraps AddLabelsWithContext using context.Background
+func (c *GitHubClient) AddLabels(labelableID string, labelIDs []string) error {
+	return c.AddLabelsWithContext(context.Background(), labelableID, labelIDs)
+}
+
+// AddLabelsWithContext adds labels to a discussion or other labelable node
+func (c *GitHubClient) AddLabelsWithContext(ctx context.Context, labelableID string, labelIDs []string) error {
+	query := `
+		mutation AddLabels($input: AddLabelsToLabelableInput!) {
+			addLabelsToLabelable(input: $input) {
+				clientMutationId
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"labelableId": labelableID,
+			"labelIds":    labelIDs,
+		},
+	}
+
+	err := c.do(ctx, query, variables, nil)
+	if err != nil {
+		return fmt.Errorf("failed to add labels: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveLabels wraps RemoveLabelsWithContext using context.Background
+func (c *GitHubClient) RemoveLabels(labelableID string, labelIDs []string) error {
+	return c.RemoveLabelsWithContext(context.Background(), labelableID, labelIDs)
+}
+
+// RemoveLabelsWithContext removes labels from a discussion or other labelable node
+func (c *GitHubClient) RemoveLabelsWithContext(ctx context.Context, labelableID string, labelIDs []string) error {
+	query := `
+		mutation RemoveLabels($input: RemoveLabelsFromLabelableInput!) {
+			removeLabelsFromLabelable(input: $input) {
+				clientMutationId
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"labelableId": labelableID,
+			"labelIds":    labelIDs,
+		},
+	}
+
+	err := c.do(ctx, query, variables, nil)
+	if err != nil {
+		return fmt.Errorf("failed to remove labels: %w", err)
+	}
+
+	return nil
+}
+
+// CloseDiscussion wraps CloseDiscussionWithContext using context.Background
+func (c *GitHubClient) CloseDiscussion(discussionID, reason string) (*models.Discussion, error) {
+	return c.CloseDiscussionWithContext(context.Background(), discussionID, reason)
+}
+
+// CloseDiscussionWithContext closes a discussion with the given reason (RESOLVED, OUTDATED, or DUPLICATE)
+func (c *GitHubClient) CloseDiscussionWithContext(ctx context.Context, discussionID, reason string) (*models.Discussion, error) {
+	query := `
+		mutation CloseDiscussion($input: CloseDiscussionInput!) {
+			closeDiscussion(input: $input) {
+				discussion {
+					id
+					number
+					title
+					url
+					closed
+					closedAt
+					stateReason
+				}
+			}
+		}`
+
+	input := map[string]interface{}{
+		"discussionId": discussionID,
+	}
+	if reason != "" {
+		input["reason"] = reason
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response struct {
+		CloseDiscussion struct {
+			Discussion *models.Discussion `json:"discussion"`
+		} `json:"closeDiscussion"`
+	}
+
+	err := c.do(ctx, query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to close discussion: %w", err)
+	}
+
+	if response.CloseDiscussion.Discussion == nil {
+		return nil, fmt.Errorf("failed to close discussion: empty response")
+	}
+
+	return response.CloseDiscussion.Discussion, nil
+}
+
+// ReopenDiscussion wraps ReopenDiscussionWithContext using context.Background
+func (c *GitHubClient) ReopenDiscussion(discussionID string) (*models.Discussion, error) {
+	return c.ReopenDiscussionWithContext(context.Background(), discussionID)
+}
+
+// ReopenDiscussionWithContext reopens a closed discussion
+func (c *GitHubClient) ReopenDiscussionWithContext(ctx context.Context, discussionID string) (*models.Discussion, error) {
+	query := `
+		mutation ReopenDiscussion($input: ReopenDiscussionInput!) {
+			reopenDiscussion(input: $input) {
+				discussion {
+					id
+					number
+					title
+					url
+					closed
+					closedAt
+					stateReason
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"discussionId": discussionID,
+		},
+	}
+
+	var response struct {
+		ReopenDiscussion struct {
+			Discussion *models.Discussion `json:"discussion"`
+		} `json:"reopenDiscussion"`
+	}
+
+	err := c.do(ctx, query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reopen discussion: %w", err)
+	}
+
+	if response.ReopenDiscussion.Discussion == nil {
+		return nil, fmt.Errorf("failed to reopen discussion: empty response")
+	}
+
+	return response.ReopenDiscussion.Discussion, nil
+}
+
+// LockDiscussion wraps LockDiscussionWithContext using context.Background
+func (c *GitHubClient) LockDiscussion(discussionID, reason string) error {
+	return c.LockDiscussionWithContext(context.Background(), discussionID, reason)
+}
+
+// LockDiscussionWithContext locks the conversation of a discussion with an optional reason
+// (OFF_TOPIC, TOO_HEATED, RESOLVED, or SPAM)
+func (c *GitHubClient) LockDiscussionWithContext(ctx context.Context, discussionID, reason string) error {
+	query := `
+		mutation LockDiscussion($input: LockLockableInput!) {
+			lockLockable(input: $input) {
+				lockedRecord {
+					locked
+				}
+			}
+		}`
+
+	input := map[string]interface{}{
+		"lockableId": discussionID,
+	}
+	if reason != "" {
+		input["lockReason"] = reason
+	}
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	err := c.do(ctx, query, variables, nil)
+	if err != nil {
+		return fmt.Errorf("failed to lock discussion: %w", err)
+	}
+
+	return nil
+}
+
+// UnlockDiscussion wraps UnlockDiscussionWithContext using context.Background
+func (c *GitHubClient) UnlockDiscussion(discussionID string) error {
+	return c.UnlockDiscussionWithContext(context.Background(), discussionID)
+}
+
+// UnlockDiscussionWithContext unlocks the conversation of a discussion
+func (c *GitHubClient) UnlockDiscussionWithContext(ctx context.Context, discussionID string) error {
+	query := `
+		mutation UnlockDiscussion($input: UnlockLockableInput!) {
+			unlockLockable(input: $input) {
+				unlockedRecord {
+					locked
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"lockableId": discussionID,
+		},
+	}
+
+	err := c.do(ctx, query, variables, nil)
+	if err != nil {
+		return fmt.Errorf("failed to unlock discussion: %w", err)
+	}
+
+	return nil
+}
+
+// GetDiscussionComment wraps GetDiscussionCommentWithContext using context.Background
+func (c *GitHubClient) GetDiscussionComment(commentID string) (*models.Comment, error) {
+	return c.GetDiscussionCommentWithContext(context.Background(), commentID)
+}
+
+// GetDiscussionCommentWithContext retrieves a discussion comment by node ID, along with
+// the discussion it belongs to
+func (c *GitHubClient) GetDiscussionCommentWithContext(ctx context.Context, commentID string) (*models.Comment, error) {
+	query := `
+		query GetDiscussionComment($id: ID!) {
+			node(id: $id) {
+				... on DiscussionComment {
+					id
+					databaseId
+					url
+					isAnswer
+					viewerCanMarkAsAnswer
+					viewerCanUnmarkAsAnswer
+					viewerCanDelete
+					replyTo {
+						id
+						url
+					}
+					discussion {
+						id
+						number
+						title
+						url
+						category {
+							name
+							isAnswerable
+						}
+						repository {
+							name
+							owner {
+								login
+							}
+						}
+					}
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"id": commentID,
+	}
+
+	var response struct {
+		Node *models.Comment `json:"node"`
+	}
+
+	err := c.do(ctx, query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	if response.Node == nil || response.Node.ID == "" {
+		return nil, newError(ErrNotFound, "comment %s not found", commentID)
+	}
+
+	return response.Node, nil
+}
+
+// MarkCommentAsAnswer wraps MarkCommentAsAnswerWithContext using context.Background
+func (c *GitHubClient) MarkCommentAsAnswer(commentID string) error {
+	return c.MarkCommentAsAnswerWithContext(context.Background(), commentID)
+}
+
+// MarkCommentAsAnswerWithContext marks a discussion comment as the answer
+func (c *GitHubClient) MarkCommentAsAnswerWithContext(ctx context.Context, commentID string) error {
+	query := `
+		mutation MarkCommentAsAnswer($input: MarkDiscussionCommentAsAnswerInput!) {
+			markDiscussionCommentAsAnswer(input: $input) {
+				discussion {
+					id
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"id": commentID,
+		},
+	}
+
+	err := c.do(ctx, query, variables, nil)
+	if err != nil {
+		return fmt.Errorf("failed to mark comment as answer: %w", err)
+	}
+
+	return nil
+}
+
+// UnmarkCommentAsAnswer wraps UnmarkCommentAsAnswerWithContext using context.Background
+func (c *GitHubClient) UnmarkCommentAsAnswer(commentID string) error {
+	return c.UnmarkCommentAsAnswerWithContext(context.Background(), commentID)
+}
+
+// UnmarkCommentAsAnswerWithContext unmarks a discussion comment as the answer
+func (c *GitHubClient) UnmarkCommentAsAnswerWithContext(ctx context.Context, commentID string) error {
+	query := `
+		mutation UnmarkCommentAsAnswer($input: UnmarkDiscussionCommentAsAnswerInput!) {
+			unmarkDiscussionCommentAsAnswer(input: $input) {
+				discussion {
+					id
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"id": commentID,
+		},
+	}
+
+	err := c.do(ctx, query, variables, nil)
+	if err != nil {
+		return fmt.Errorf("failed to unmark comment as answer: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteDiscussion wraps DeleteDiscussionWithContext using context.Background
+func (c *GitHubClient) DeleteDiscussion(discussionID string) error {
+	return c.DeleteDiscussionWithContext(context.Background(), discussionID)
+}
+
+// DeleteDiscussionWithContext deletes a discussion
+func (c *GitHubClient) DeleteDiscussionWithContext(ctx context.Context, discussionID string) error {
+	query := `
+		mutation DeleteDiscussion($input: DeleteDiscussionInput!) {
+			deleteDiscussion(input: $input) {
+				discussion {
+					id
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"id": discussionID,
+		},
+	}
+
+	err := c.do(ctx, query, variables, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete discussion: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteDiscussionComment wraps DeleteDiscussionCommentWithContext using context.Background
+func (c *GitHubClient) DeleteDiscussionComment(commentID string) error {
+	return c.DeleteDiscussionCommentWithContext(context.Background(), commentID)
+}
+
+// DeleteDiscussionCommentWithContext deletes a discussion comment
+func (c *GitHubClient) DeleteDiscussionCommentWithContext(ctx context.Context, commentID string) error {
+	query := `
+		mutation DeleteDiscussionComment($input: DeleteDiscussionCommentInput!) {
+			deleteDiscussionComment(input: $input) {
+				comment {
+					id
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"id": commentID,
+		},
+	}
+
+	err := c.do(ctx, query, variables, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	return nil
+}
+
+// SetDiscussionsEnabled wraps SetDiscussionsEnabledWithContext using context.Background
+func (c *GitHubClient) SetDiscussionsEnabled(owner, repo string, enabled bool) error {
+	return c.SetDiscussionsEnabledWithContext(context.Background(), owner, repo, enabled)
+}
+
+// SetDiscussionsEnabledWithContext turns discussions on or off for a repository.
+// GraphQL has no mutation for this, so it uses the REST API, which requires
+// admin access to the repository.
+func (c *GitHubClient) SetDiscussionsEnabledWithContext(ctx context.Context, owner, repo string, enabled bool) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"has_discussions": enabled,
+	})
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("repos/%s/%s", owner, repo)
+	reqCtx, cancel := c.requestContext(ctx)
+	defer cancel()
+	err = c.rest.DoWithContext(reqCtx, "PATCH", path, bytes.NewReader(body), nil)
+	if err != nil {
+		return fmt.Errorf("failed to update repository settings: %w", classifyError(err))
 	}
 
-	return "", nil // Category not found, but not an error
+	return nil
 }
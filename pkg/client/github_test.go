@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/harakeishi/gh-discussion/pkg/models"
+)
+
+func TestFindDiscussionCommentFollowsReplyCursors(t *testing.T) {
+	stub := &stubTransport{responses: map[string]string{
+		"FindDiscussionComment": `{"data":{"repository":{"discussion":{"comments":{
+			"pageInfo":{"hasNextPage":false,"endCursor":"c1"},
+			"nodes":[{"id":"DC_parent","databaseId":100,"replies":{
+				"pageInfo":{"hasNextPage":true,"endCursor":"r100"},
+				"nodes":[{"id":"DC_first","databaseId":101,"replyTo":{"id":"DC_parent"}}]
+			}}]
+		}}}}}`,
+		"GetCommentReplies": `{"data":{"node":{"replies":{
+			"totalCount":101,
+			"pageInfo":{"hasNextPage":false,"endCursor":"r101"},
+			"nodes":[{"id":"DC_last","databaseId":201,"replyTo":{"id":"DC_parent"}}]
+		}}}}`,
+	}}
+	c := newTestClient(t, stub)
+	ctx := context.Background()
+
+	comment, err := c.FindDiscussionCommentWithContext(ctx, "octo", "hello", 1, 201)
+	if err != nil {
+		t.Fatalf("FindDiscussionCommentWithContext() error = %v", err)
+	}
+	if comment.ID != "DC_last" || comment.ReplyTo == nil || comment.ReplyTo.ID != "DC_parent" {
+		t.Errorf("found comment %+v, want DC_last replying to DC_parent", comment)
+	}
+	if stub.calls != 2 {
+		t.Errorf("made %d requests, want 2", stub.calls)
+	}
+
+	_, err = c.FindDiscussionCommentWithContext(ctx, "octo", "hello", 1, 999)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindDiscussionCommentWithContext() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestListDiscussionsEmpty(t *testing.T) {
+	stub := &stubTransport{responses: map[string]string{
+		"ListDiscussions": `{"data":{"repository":{"hasDiscussionsEnabled":true,"discussions":{
+			"pageInfo":{"hasNextPage":false,"endCursor":null},
+			"nodes":[]
+		}}}}`,
+	}}
+	c := newTestClient(t, stub)
+
+	result, err := c.ListDiscussionsWithContext(context.Background(), models.ListOptions{Owner: "octo", Repo: "hello", State: "open"})
+	if err != nil {
+		t.Fatalf("ListDiscussionsWithContext() error = %v", err)
+	}
+
+	data, err := json.Marshal(result.Nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "[]" {
+		t.Errorf("empty result encodes as %s, want []", data)
+	}
+}
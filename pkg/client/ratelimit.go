@@ -0,0 +1,289 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/harakeishi/gh-discussion/pkg/models"
+)
+
+const (
+	// maxRetries is how many times a rate limited request is retried
+	maxRetries = 5
+	// baseBackoff is the delay before the first retry; it doubles on every attempt
+	baseBackoff = 5 * time.Second
+	// maxBackoff caps the exponential backoff delay
+	maxBackoff = time.Minute
+	// maxRateLimitWait is the longest the client waits for a rate limit to reset
+	// before giving up
+	maxRateLimitWait = 15 * time.Minute
+)
+
+// rateLimitSelection is added to every query so that the client can track how
+// much of its GraphQL budget is left
+const rateLimitSelection = "rateLimit { cost remaining resetAt }"
+
+// sleep pauses between retries, returning early with the context's error
+// when it is cancelled
+var sleep = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rateLimitedResponse decodes the rateLimit field of a query alongside the
+// caller's response
+type rateLimitedResponse struct {
+	data      interface{}
+	rateLimit *models.RateLimit
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (r *rateLimitedResponse) UnmarshalJSON(b []byte) error {
+	var rl struct {
+		RateLimit *models.RateLimit `json:"rateLimit"`
+	}
+	if err := json.Unmarshal(b, &rl); err != nil {
+		return err
+	}
+	r.rateLimit = rl.RateLimit
+
+	if r.data == nil {
+		return nil
+	}
+	return json.Unmarshal(b, r.data)
+}
+
+// do executes a GraphQL request, tracking the rate limit reported by queries
+// and retrying with jittered backoff when GitHub rate limits the request
+func (c *GitHubClient) do(ctx context.Context, query string, variables map[string]interface{}, response interface{}) error {
+	query = withRateLimit(query)
+
+	for attempt := 0; ; attempt++ {
+		if err := c.waitForReset(ctx); err != nil {
+			return err
+		}
+
+		wrapped := &rateLimitedResponse{data: response}
+		reqCtx, cancel := c.requestContext(ctx)
+		err := c.client.DoWithContext(reqCtx, query, variables, wrapped)
+		cancel()
+		if wrapped.rateLimit != nil {
+			c.setRateLimit(wrapped.rateLimit)
+		}
+		if err == nil {
+			return nil
+		}
+
+		wait, ok := c.retryDelay(err, attempt)
+		if !ok || attempt >= maxRetries {
+			return classifyError(err)
+		}
+		c.notify("Rate limited by GitHub; retrying in %s\n", wait.Round(time.Second))
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// notify writes a message about rate limit waits to the client's notices
+// writer, if one is set
+func (c *GitHubClient) notify(format string, args ...interface{}) {
+	if c.notices != nil {
+		fmt.Fprintf(c.notices, format, args...)
+	}
+}
+
+// requestContext returns the context for a single API request, which is
+// limited by the client's timeout when one is set
+func (c *GitHubClient) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// withRateLimit adds the rateLimit selection to the top level of a query.
+// Mutations are returned unchanged because rateLimit is only available on the
+// query root.
+func withRateLimit(query string) string {
+	trimmed := strings.TrimSpace(query)
+	if !strings.HasPrefix(trimmed, "query") || strings.Contains(query, "rateLimit") {
+		return query
+	}
+
+	// Find the opening brace of the selection set, skipping variable definitions
+	depth := 0
+	for i, r := range query {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '{':
+			if depth == 0 {
+				return query[:i+1] + "\n\t\t\t" + rateLimitSelection + query[i+1:]
+			}
+		}
+	}
+	return query
+}
+
+// lastRateLimit returns the rate limit status reported by the most recent query
+func (c *GitHubClient) lastRateLimit() *models.RateLimit {
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	return c.rateLimit
+}
+
+// setRateLimit records the rate limit status reported by a query
+func (c *GitHubClient) setRateLimit(rl *models.RateLimit) {
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	c.rateLimit = rl
+}
+
+// waitForReset blocks until the rate limit resets when the last query used up
+// the remaining budget
+func (c *GitHubClient) waitForReset(ctx context.Context) error {
+	rl := c.lastRateLimit()
+	if rl == nil || rl.Remaining > 0 {
+		return nil
+	}
+
+	wait := time.Until(rl.ResetAt)
+	if wait <= 0 {
+		return nil
+	}
+	if wait > maxRateLimitWait {
+		return newError(ErrRateLimited, "GitHub API rate limit exceeded; it resets at %s", rl.ResetAt.Local().Format(time.Kitchen))
+	}
+
+	c.notify("GitHub API rate limit exhausted; waiting %s for it to reset\n", wait.Round(time.Second))
+	if err := sleep(ctx, wait); err != nil {
+		return err
+	}
+
+	// Forget the exhausted limit unless another request has already replaced it
+	c.rateLimitMu.Lock()
+	if c.rateLimit == rl {
+		c.rateLimit = nil
+	}
+	c.rateLimitMu.Unlock()
+	return nil
+}
+
+// retryDelay reports whether err is a rate limit error worth retrying and how
+// long to wait before the next attempt
+func (c *GitHubClient) retryDelay(err error, attempt int) (time.Duration, bool) {
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) {
+		if httpErr.StatusCode != http.StatusForbidden && httpErr.StatusCode != http.StatusTooManyRequests {
+			return 0, false
+		}
+
+		// GitHub asks clients to honour Retry-After for secondary rate limits
+		if seconds, err := strconv.Atoi(httpErr.Headers.Get("Retry-After")); err == nil {
+			return limitWait(time.Duration(seconds)*time.Second + jitter(time.Second))
+		}
+		if httpErr.Headers.Get("X-Ratelimit-Remaining") == "0" {
+			if reset, err := strconv.ParseInt(httpErr.Headers.Get("X-Ratelimit-Reset"), 10, 64); err == nil {
+				return limitWait(time.Until(time.Unix(reset, 0)) + jitter(time.Second))
+			}
+		}
+		if strings.Contains(strings.ToLower(httpErr.Message), "secondary rate limit") {
+			return backoff(attempt), true
+		}
+		return 0, false
+	}
+
+	var gqlErr *api.GraphQLError
+	if errors.As(err, &gqlErr) {
+		for _, e := range gqlErr.Errors {
+			if e.Type != "RATE_LIMITED" {
+				continue
+			}
+			if rl := c.lastRateLimit(); rl != nil && rl.Remaining == 0 {
+				return limitWait(time.Until(rl.ResetAt) + jitter(time.Second))
+			}
+			return backoff(attempt), true
+		}
+	}
+
+	return 0, false
+}
+
+// limitWait accepts a wait for a rate limit reset unless it is too long to be
+// worth blocking on
+func limitWait(wait time.Duration) (time.Duration, bool) {
+	if wait > maxRateLimitWait {
+		return 0, false
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
+
+// backoff returns the exponential backoff delay for an attempt with up to 50%
+// random jitter added, so that parallel jobs do not retry in lockstep
+func backoff(attempt int) time.Duration {
+	d := baseBackoff << attempt
+	if d > maxBackoff || d <= 0 {
+		d = maxBackoff
+	}
+	return d + jitter(d/2)
+}
+
+// jitter returns a random duration in [0, max)
+func jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return rand.N(max)
+}
+
+// GetRateLimit wraps GetRateLimitWithContext using context.Background
+func (c *GitHubClient) GetRateLimit() (*models.RateLimit, error) {
+	return c.GetRateLimitWithContext(context.Background())
+}
+
+// GetRateLimitWithContext retrieves the current GraphQL API rate limit status
+func (c *GitHubClient) GetRateLimitWithContext(ctx context.Context) (*models.RateLimit, error) {
+	query := `
+		query RateLimit {
+			rateLimit {
+				limit
+				cost
+				remaining
+				used
+				resetAt
+			}
+		}
+	`
+
+	var response struct {
+		RateLimit models.RateLimit `json:"rateLimit"`
+	}
+
+	err := c.do(ctx, query, nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rate limit: %w", err)
+	}
+
+	return &response.RateLimit, nil
+}
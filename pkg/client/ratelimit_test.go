@@ -0,0 +1,269 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+
+	"github.com/harakeishi/gh-discussion/pkg/models"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "query with variables",
+			query: `query Get($first: Int!, $after: String) { repository(owner: "o", name: "r") { id } }`,
+			want:  "query Get($first: Int!, $after: String) {\n\t\t\t" + rateLimitSelection + ` repository(owner: "o", name: "r") { id } }`,
+		},
+		{
+			name:  "anonymous query",
+			query: `query { viewer { login } }`,
+			want:  "query {\n\t\t\t" + rateLimitSelection + ` viewer { login } }`,
+		},
+		{
+			name:  "mutation",
+			query: `mutation Add($input: AddInput!) { add(input: $input) { id } }`,
+			want:  `mutation Add($input: AddInput!) { add(input: $input) { id } }`,
+		},
+		{
+			name:  "already selected",
+			query: `query { rateLimit { limit } }`,
+			want:  `query { rateLimit { limit } }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withRateLimit(tt.query); got != tt.want {
+				t.Errorf("withRateLimit() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		base    time.Duration
+	}{
+		{attempt: 0, base: baseBackoff},
+		{attempt: 1, base: 2 * baseBackoff},
+		{attempt: 3, base: 8 * baseBackoff},
+		{attempt: 4, base: maxBackoff},
+		{attempt: 100, base: maxBackoff},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			got := backoff(tt.attempt)
+			if got < tt.base || got >= tt.base+tt.base/2 {
+				t.Fatalf("backoff(%d) = %s, want within [%s, %s)", tt.attempt, got, tt.base, tt.base+tt.base/2)
+			}
+		}
+	}
+
+	if got := jitter(0); got != 0 {
+		t.Errorf("jitter(0) = %s, want 0", got)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	httpErr := func(status int, message string, header map[string]string) error {
+		h := http.Header{}
+		for name, value := range header {
+			h.Set(name, value)
+		}
+		return &api.HTTPError{StatusCode: status, Message: message, Headers: h}
+	}
+	rateLimited := &api.GraphQLError{Errors: []api.GraphQLErrorItem{{Type: "RATE_LIMITED", Message: "API rate limit exceeded"}}}
+	resetIn := func(d time.Duration) string {
+		return strconv.FormatInt(time.Now().Add(d).Unix(), 10)
+	}
+
+	tests := []struct {
+		name      string
+		err       error
+		rateLimit *models.RateLimit
+		attempt   int
+		wantOK    bool
+		min, max  time.Duration
+	}{
+		{
+			name:   "retry-after header",
+			err:    httpErr(http.StatusForbidden, "secondary rate limit", map[string]string{"Retry-After": "3"}),
+			wantOK: true, min: 3 * time.Second, max: 4 * time.Second,
+		},
+		{
+			name:   "primary limit reset header",
+			err:    httpErr(http.StatusForbidden, "API rate limit exceeded", map[string]string{"X-Ratelimit-Remaining": "0", "X-Ratelimit-Reset": resetIn(30 * time.Second)}),
+			wantOK: true, min: 28 * time.Second, max: 32 * time.Second,
+		},
+		{
+			name: "primary limit resetting too late",
+			err:  httpErr(http.StatusForbidden, "API rate limit exceeded", map[string]string{"X-Ratelimit-Remaining": "0", "X-Ratelimit-Reset": resetIn(time.Hour)}),
+		},
+		{
+			name:    "secondary limit without headers",
+			err:     httpErr(http.StatusTooManyRequests, "You have exceeded a secondary rate limit", nil),
+			attempt: 1,
+			wantOK:  true, min: 2 * baseBackoff, max: 3 * baseBackoff,
+		},
+		{
+			name: "forbidden",
+			err:  httpErr(http.StatusForbidden, "Resource not accessible by integration", nil),
+		},
+		{
+			name: "server error",
+			err:  httpErr(http.StatusBadGateway, "Bad Gateway", map[string]string{"Retry-After": "1"}),
+		},
+		{
+			name:      "graphql limit with known reset",
+			err:       rateLimited,
+			rateLimit: &models.RateLimit{Remaining: 0, ResetAt: time.Now().Add(10 * time.Second)},
+			wantOK:    true, min: 8 * time.Second, max: 12 * time.Second,
+		},
+		{
+			name:   "graphql limit without reset",
+			err:    rateLimited,
+			wantOK: true, min: baseBackoff, max: baseBackoff + baseBackoff/2,
+		},
+		{
+			name: "other graphql error",
+			err:  &api.GraphQLError{Errors: []api.GraphQLErrorItem{{Type: "NOT_FOUND"}}},
+		},
+		{
+			name: "network error",
+			err:  errors.New("connection reset"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &GitHubClient{rateLimit: tt.rateLimit}
+			wait, ok := c.retryDelay(tt.err, tt.attempt)
+			if ok != tt.wantOK {
+				t.Fatalf("retryDelay() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && (wait < tt.min || wait > tt.max) {
+				t.Errorf("retryDelay() = %s, want within [%s, %s]", wait, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+// stubSleep replaces sleep for the duration of a test and records the
+// requested waits instead of blocking
+func stubSleep(t *testing.T) *[]time.Duration {
+	t.Helper()
+
+	var mu sync.Mutex
+	var waits []time.Duration
+	original := sleep
+	sleep = func(ctx context.Context, d time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	t.Cleanup(func() { sleep = original })
+	return &waits
+}
+
+func TestDoRetriesRateLimitedQueries(t *testing.T) {
+	waits := stubSleep(t)
+
+	calls := 0
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			resp := stubResponse(req, http.StatusForbidden, `{"message":"You have exceeded a secondary rate limit"}`)
+			resp.Header.Set("Retry-After", "2")
+			return resp, nil
+		}
+		return stubResponse(req, http.StatusOK, `{"data":{"rateLimit":{"cost":1,"remaining":4999,"resetAt":"2030-01-01T00:00:00Z"},"viewer":{"login":"octocat"}}}`), nil
+	})
+
+	var notices bytes.Buffer
+	c := newTestClient(t, transport)
+	c.notices = &notices
+
+	var response struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
+	}
+	if err := c.do(context.Background(), `query { viewer { login } }`, nil, &response); err != nil {
+		t.Fatalf("do() error = %v", err)
+	}
+	if response.Viewer.Login != "octocat" {
+		t.Errorf("login = %q, want octocat", response.Viewer.Login)
+	}
+	if calls != 2 || len(*waits) != 1 || (*waits)[0] < 2*time.Second {
+		t.Errorf("calls = %d, waits = %v, want one retry after at least 2s", calls, *waits)
+	}
+	if !strings.Contains(notices.String(), "Rate limited by GitHub; retrying in") {
+		t.Errorf("notices = %q, want a retry notice", notices.String())
+	}
+	if rl := c.lastRateLimit(); rl == nil || rl.Remaining != 4999 {
+		t.Errorf("tracked rate limit = %+v, want 4999 remaining", rl)
+	}
+}
+
+func TestWaitForReset(t *testing.T) {
+	waits := stubSleep(t)
+
+	c := &GitHubClient{rateLimit: &models.RateLimit{Remaining: 0, ResetAt: time.Now().Add(time.Minute)}}
+	if err := c.waitForReset(context.Background()); err != nil {
+		t.Fatalf("waitForReset() error = %v", err)
+	}
+	if len(*waits) != 1 || (*waits)[0] <= 0 || (*waits)[0] > time.Minute {
+		t.Errorf("waits = %v, want one wait of up to a minute", *waits)
+	}
+	if c.lastRateLimit() != nil {
+		t.Error("exhausted rate limit was not cleared after waiting")
+	}
+
+	c.setRateLimit(&models.RateLimit{Remaining: 0, ResetAt: time.Now().Add(time.Hour)})
+	if err := c.waitForReset(context.Background()); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("waitForReset() error = %v, want ErrRateLimited", err)
+	}
+}
+
+func TestConcurrentRequests(t *testing.T) {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return stubResponse(req, http.StatusOK, `{"data":{"rateLimit":{"cost":1,"remaining":100,"resetAt":"2030-01-01T00:00:00Z"}}}`), nil
+	})
+	c := newTestClient(t, transport)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.do(context.Background(), `query { viewer { login } }`, nil, nil); err != nil {
+				t.Errorf("do() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
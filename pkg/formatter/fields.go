@@ -0,0 +1,97 @@
+package formatter
+
+import "strings"
+
+// fieldTree is a set of dotted field paths such as author.login, grouped by
+// their first segment. A nil subtree selects the whole value.
+type fieldTree map[string]fieldTree
+
+// newFieldTree builds a fieldTree from dotted field paths
+func newFieldTree(paths []string) fieldTree {
+	tree := fieldTree{}
+	for _, path := range paths {
+		tree.add(strings.Split(path, "."))
+	}
+	return tree
+}
+
+// add adds the path made of segments to the tree. Selecting a whole value
+// takes precedence over selecting some of its fields.
+func (t fieldTree) add(segments []string) {
+	name := segments[0]
+	if len(segments) == 1 {
+		t[name] = nil
+		return
+	}
+
+	sub, exists := t[name]
+	if exists && sub == nil {
+		return
+	}
+	if sub == nil {
+		sub = fieldTree{}
+		t[name] = sub
+	}
+	sub.add(segments[1:])
+}
+
+// union returns a tree that selects everything selected by a or b
+func union(a, b fieldTree) fieldTree {
+	if a == nil || b == nil {
+		return nil
+	}
+
+	result := fieldTree{}
+	for name, sub := range a {
+		result[name] = sub
+	}
+	for name, sub := range b {
+		if prev, exists := result[name]; exists {
+			result[name] = union(prev, sub)
+		} else {
+			result[name] = sub
+		}
+	}
+	return result
+}
+
+// project returns the parts of a decoded JSON value selected by the tree.
+// Arrays are projected element by element, and fields that are missing from
+// a connection are looked up in its nodes, so comments.author.login selects
+// the login of every comment author.
+func (t fieldTree) project(value interface{}) interface{} {
+	if t == nil {
+		return value
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		projected := make([]interface{}, len(v))
+		for i, item := range v {
+			projected[i] = t.project(item)
+		}
+		return projected
+	case map[string]interface{}:
+		projected := make(map[string]interface{})
+		var nodes fieldTree
+		for name, sub := range t {
+			if field, exists := v[name]; exists {
+				projected[name] = sub.project(field)
+			} else if _, isConnection := v["nodes"]; isConnection {
+				if nodes == nil {
+					nodes = fieldTree{}
+				}
+				nodes[name] = sub
+			}
+		}
+		if nodes != nil {
+			if explicit, exists := t["nodes"]; exists {
+				nodes = union(explicit, nodes)
+			}
+			projected["nodes"] = nodes.project(v["nodes"])
+		}
+		return projected
+	default:
+		return value
+	}
+}
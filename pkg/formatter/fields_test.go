@@ -0,0 +1,100 @@
+package formatter
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFieldTreeProject(t *testing.T) {
+	const discussion = `{
+		"number": 1,
+		"title": "How do I deploy?",
+		"author": {"login": "alice", "url": "https://github.com/alice"},
+		"labels": {"nodes": [{"name": "question", "color": "d876e3"}]},
+		"comments": {
+			"totalCount": 2,
+			"pageInfo": {"hasNextPage": false},
+			"nodes": [
+				{"body": "Use the script", "author": {"login": "bob", "url": "https://github.com/bob"}},
+				{"body": "Thanks!", "author": {"login": "alice", "url": "https://github.com/alice"}}
+			]
+		}
+	}`
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  string
+	}{
+		{
+			name:  "top-level fields",
+			paths: []string{"number", "title"},
+			want:  `{"number": 1, "title": "How do I deploy?"}`,
+		},
+		{
+			name:  "nested field",
+			paths: []string{"author.login"},
+			want:  `{"author": {"login": "alice"}}`,
+		},
+		{
+			name:  "whole value takes precedence",
+			paths: []string{"author.login", "author"},
+			want:  `{"author": {"login": "alice", "url": "https://github.com/alice"}}`,
+		},
+		{
+			name:  "missing field",
+			paths: []string{"number", "answer"},
+			want:  `{"number": 1}`,
+		},
+		{
+			name:  "connection falls back to nodes",
+			paths: []string{"comments.author.login"},
+			want:  `{"comments": {"nodes": [{"author": {"login": "bob"}}, {"author": {"login": "alice"}}]}}`,
+		},
+		{
+			name:  "connection field and node field",
+			paths: []string{"comments.totalCount", "comments.body"},
+			want:  `{"comments": {"totalCount": 2, "nodes": [{"body": "Use the script"}, {"body": "Thanks!"}]}}`,
+		},
+		{
+			name:  "explicit nodes merge with the fallback",
+			paths: []string{"labels.nodes.color", "labels.name"},
+			want:  `{"labels": {"nodes": [{"name": "question", "color": "d876e3"}]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value, want interface{}
+			if err := json.Unmarshal([]byte(discussion), &value); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+
+			got := newFieldTree(tt.paths).project(value)
+			if !reflect.DeepEqual(got, want) {
+				gotJSON, _ := json.Marshal(got)
+				t.Errorf("project() = %s, want %s", gotJSON, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldTreeProjectArray(t *testing.T) {
+	var value interface{}
+	if err := json.Unmarshal([]byte(`[{"number": 1, "title": "a"}, {"number": 2, "title": "b"}]`), &value); err != nil {
+		t.Fatal(err)
+	}
+
+	got := newFieldTree([]string{"number"}).project(value)
+	want := []interface{}{
+		map[string]interface{}{"number": float64(1)},
+		map[string]interface{}{"number": float64(2)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("project() = %v, want %v", got, want)
+	}
+}
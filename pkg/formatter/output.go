@@ -1,17 +1,21 @@
 package formatter
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"strconv"
 	"strings"
-	"text/template"
 	"time"
 
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/cli/go-gh/v2/pkg/jq"
+	"github.com/cli/go-gh/v2/pkg/markdown"
+	"github.com/cli/go-gh/v2/pkg/template"
+	"github.com/cli/go-gh/v2/pkg/term"
 	"github.com/harakeishi/gh-discussion/pkg/models"
 )
 
@@ -238,6 +242,23 @@ func (f *Formatter) formatDiscussionTable(discussion *models.Discussion) error {
 		fmt.Fprintf(f.writer, "%s %s\n", labelStyle.Render("Repository:"), valueStyle.Render(discussion.Repository.NameWithOwner))
 	}
 
+	state := "Open"
+	if discussion.Closed {
+		state = "Closed"
+		if discussion.StateReason != nil {
+			state = fmt.Sprintf("Closed (%s)", strings.ToLower(*discussion.StateReason))
+		}
+	}
+	fmt.Fprintf(f.writer, "%s %s\n", labelStyle.Render("State:"), valueStyle.Render(state))
+
+	if discussion.Locked {
+		locked := "Yes"
+		if discussion.ActiveLockReason != nil {
+			locked = fmt.Sprintf("Yes (%s)", strings.ToLower(strings.ReplaceAll(*discussion.ActiveLockReason, "_", " ")))
+		}
+		fmt.Fprintf(f.writer, "%s %s\n", labelStyle.Render("Locked:"), valueStyle.Render(locked))
+	}
+
 	fmt.Fprintf(f.writer, "%s %s\n", labelStyle.Render("Created:"), valueStyle.Render(f.formatTime(discussion.CreatedAt)))
 	fmt.Fprintf(f.writer, "%s %s\n", labelStyle.Render("Updated:"), valueStyle.Render(f.formatTime(discussion.UpdatedAt)))
 
@@ -350,42 +371,76 @@ func (f *Formatter) formatComment(comment models.Comment, number int, depth int)
 // formatDiscussionListJSON formats discussions as JSON
 func (f *Formatter) formatDiscussionListJSON(discussions []models.Discussion) error {
 	if len(f.opts.Fields) > 0 {
-		filtered := f.filterFields(discussions, f.opts.Fields)
-		return json.NewEncoder(f.writer).Encode(filtered)
+		return f.writeJSON(f.filterFields(discussions, f.opts.Fields))
 	}
-	return json.NewEncoder(f.writer).Encode(discussions)
+	return f.writeJSON(discussions)
 }
 
 // formatDiscussionJSON formats a single discussion as JSON
 func (f *Formatter) formatDiscussionJSON(discussion *models.Discussion) error {
 	if len(f.opts.Fields) > 0 {
-		filtered := f.filterFields(discussion, f.opts.Fields)
-		return json.NewEncoder(f.writer).Encode(filtered)
+		return f.writeJSON(f.filterFields(discussion, f.opts.Fields))
 	}
-	return json.NewEncoder(f.writer).Encode(discussion)
+	return f.writeJSON(discussion)
 }
 
-// formatDiscussionListTemplate formats discussions using a template
-func (f *Formatter) formatDiscussionListTemplate(discussions []models.Discussion) error {
-	tmpl, err := template.New("discussions").Parse(f.opts.Template)
-	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+// writeJSON writes data as JSON, passing it through the jq filter if one is set
+func (f *Formatter) writeJSON(data interface{}) error {
+	if f.opts.JQFilter == "" {
+		return json.NewEncoder(f.writer).Encode(data)
 	}
 
-	return tmpl.Execute(f.writer, discussions)
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(data); err != nil {
+		return err
+	}
+	if err := jq.Evaluate(&buf, f.writer, f.opts.JQFilter); err != nil {
+		return fmt.Errorf("failed to apply jq filter: %w", err)
+	}
+	return nil
+}
+
+// formatDiscussionListTemplate formats discussions using a template
+func (f *Formatter) formatDiscussionListTemplate(discussions []models.Discussion) error {
+	return f.executeTemplate(discussions)
 }
 
 // formatDiscussionTemplate formats a single discussion using a template
 func (f *Formatter) formatDiscussionTemplate(discussion *models.Discussion) error {
-	tmpl, err := template.New("discussion").Parse(f.opts.Template)
+	return f.executeTemplate(discussion)
+}
+
+// executeTemplate renders data with the user's template. The template sees the
+// JSON representation of the data, so fields use their JSON names such as
+// .number, and it can use the helper functions of gh such as timeago,
+// tablerow, and color, as well as markdown.
+func (f *Formatter) executeTemplate(data interface{}) error {
+	terminal := term.FromEnv()
+	width, _, err := terminal.Size()
 	if err != nil {
+		width = 80
+	}
+
+	tmpl := template.New(f.writer, width, terminal.IsColorEnabled()).Funcs(map[string]interface{}{
+		"markdown": func(text string) (string, error) {
+			return markdown.Render(text, markdown.WithTheme(terminal.Theme()), markdown.WithWrap(width))
+		},
+	})
+	if err := tmpl.Parse(f.opts.Template); err != nil {
 		return fmt.Errorf("failed to parse template: %w", err)
 	}
 
-	return tmpl.Execute(f.writer, discussion)
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if err := tmpl.Execute(bytes.NewReader(jsonData)); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return tmpl.Flush()
 }
 
-// filterFields filters the data to include only specified fields
+// filterFields filters the data to include only the specified field paths
 func (f *Formatter) filterFields(data interface{}, fields []string) interface{} {
 	// Convert to JSON and back to get a map representation
 	jsonData, err := json.Marshal(data)
@@ -398,29 +453,7 @@ func (f *Formatter) filterFields(data interface{}, fields []string) interface{}
 		return data
 	}
 
-	return f.filterFieldsRecursive(result, fields)
-}
-
-// filterFieldsRecursive recursively filters fields from the data
-func (f *Formatter) filterFieldsRecursive(data interface{}, fields []string) interface{} {
-	switch v := data.(type) {
-	case map[string]interface{}:
-		filtered := make(map[string]interface{})
-		for _, field := range fields {
-			if value, exists := v[field]; exists {
-				filtered[field] = value
-			}
-		}
-		return filtered
-	case []interface{}:
-		filtered := make([]interface{}, len(v))
-		for i, item := range v {
-			filtered[i] = f.filterFieldsRecursive(item, fields)
-		}
-		return filtered
-	default:
-		return data
-	}
+	return newFieldTree(fields).project(result)
 }
 
 // formatTime formats a time value for display
@@ -465,31 +498,3 @@ func (f *Formatter) truncateString(s string, maxLen int) string {
 	}
 	return string(runes[:maxLen-3]) + "..."
 }
-
-// GetAvailableFields returns the available fields for JSON output
-func GetAvailableFields() map[string][]string {
-	return map[string][]string{
-		"discussion": {
-			"activeLockReason", "answer", "answerChosenAt", "answerChosenBy", "author", "authorAssociation",
-			"body", "bodyHTML", "bodyText", "category", "comments", "createdAt", "createdViaEmail", "databaseId",
-			"editor", "id", "includesCreatedEdit", "isAnswered", "lastEditedAt", "locked", "number",
-			"publishedAt", "reactionGroups", "reactions", "repository", "resourcePath", "title", "updatedAt",
-			"url", "userContentEdits", "viewerCanDelete", "viewerCanReact", "viewerCanSubscribe",
-			"viewerCanUpdate", "viewerDidAuthor", "viewerSubscription",
-		},
-		"author": {
-			"avatarUrl", "login", "url", "id", "name", "email",
-		},
-		"category": {
-			"id", "name", "description", "emoji", "emojiHTML", "isAnswerable", "createdAt", "updatedAt",
-		},
-		"comments": {
-			"author", "authorAssociation", "body", "bodyHTML", "bodyText", "createdAt", "id", "isAnswer",
-			"isMinimized", "minimizedReason", "publishedAt", "reactionGroups", "replies", "replyTo",
-			"updatedAt", "url", "viewerCanMarkAsAnswer", "viewerCanUnmarkAsAnswer",
-		},
-		"repository": {
-			"id", "name", "nameWithOwner", "owner", "url", "description",
-		},
-	}
-}
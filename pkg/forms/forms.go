@@ -0,0 +1,285 @@
+package forms
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FieldType represents the type of a discussion form field
+type FieldType string
+
+const (
+	FieldMarkdown   FieldType = "markdown"
+	FieldInput      FieldType = "input"
+	FieldTextarea   FieldType = "textarea"
+	FieldDropdown   FieldType = "dropdown"
+	FieldCheckboxes FieldType = "checkboxes"
+)
+
+// noResponse is the placeholder GitHub renders for fields left empty
+const noResponse = "_No response_"
+
+// Template represents a discussion category form from .github/DISCUSSION_TEMPLATE
+type Template struct {
+	Title  string   `yaml:"title"`
+	Labels []string `yaml:"labels"`
+	Body   []Field  `yaml:"body"`
+}
+
+// Field represents a single element of a discussion form
+type Field struct {
+	Type        FieldType   `yaml:"type"`
+	ID          string      `yaml:"id"`
+	Attributes  Attributes  `yaml:"attributes"`
+	Validations Validations `yaml:"validations"`
+}
+
+// Attributes holds the attributes of a form field
+type Attributes struct {
+	Label       string   `yaml:"label"`
+	Description string   `yaml:"description"`
+	Placeholder string   `yaml:"placeholder"`
+	Value       string   `yaml:"value"`
+	Render      string   `yaml:"render"`
+	Multiple    bool     `yaml:"multiple"`
+	Default     *int     `yaml:"default"`
+	Options     []Option `yaml:"options"`
+}
+
+// Validations holds the validation rules of a form field
+type Validations struct {
+	Required bool `yaml:"required"`
+}
+
+// Option represents a dropdown or checkboxes option
+type Option struct {
+	Label    string `yaml:"label"`
+	Required bool   `yaml:"required"`
+}
+
+// UnmarshalYAML accepts both plain dropdown options and checkbox option mappings
+func (o *Option) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		o.Label = node.Value
+		return nil
+	}
+
+	type plain Option
+	return node.Decode((*plain)(o))
+}
+
+// Parse parses a discussion form template
+func Parse(data []byte) (*Template, error) {
+	var t Template
+	if err := yaml.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("failed to parse discussion template: %w", err)
+	}
+
+	for i, field := range t.Body {
+		switch field.Type {
+		case FieldMarkdown, FieldInput, FieldTextarea, FieldDropdown, FieldCheckboxes:
+		default:
+			return nil, fmt.Errorf("failed to parse discussion template: unsupported field type %q at body[%d]", field.Type, i)
+		}
+	}
+
+	return &t, nil
+}
+
+// Inputs returns the fields that accept a value, skipping markdown fields
+func (t *Template) Inputs() []Field {
+	var fields []Field
+	for _, field := range t.Body {
+		if field.Type != FieldMarkdown {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Key returns the name used to supply a value for the field with --field
+func (f Field) Key() string {
+	if f.ID != "" {
+		return f.ID
+	}
+	return f.Attributes.Label
+}
+
+// OptionLabels returns the labels of the field's options
+func (f Field) OptionLabels() []string {
+	labels := make([]string, len(f.Attributes.Options))
+	for i, option := range f.Attributes.Options {
+		labels[i] = option.Label
+	}
+	return labels
+}
+
+// DefaultValue returns the value the web form would prefill for the field
+func (f Field) DefaultValue() string {
+	switch f.Type {
+	case FieldDropdown:
+		if d := f.Attributes.Default; d != nil && *d >= 0 && *d < len(f.Attributes.Options) {
+			return f.Attributes.Options[*d].Label
+		}
+		return ""
+	default:
+		return f.Attributes.Value
+	}
+}
+
+// SplitValues splits a comma-separated value for multi-select fields
+func SplitValues(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// SplitFlagValues expands comma-separated --field values of dropdown and
+// checkboxes fields into separate options. A value that is exactly one of the
+// field's options is kept whole, so options containing commas can be chosen.
+func (t *Template) SplitFlagValues(values map[string][]string) map[string][]string {
+	result := make(map[string][]string, len(values))
+	for key, raw := range values {
+		result[key] = raw
+	}
+
+	for _, field := range t.Inputs() {
+		raw, ok := values[field.Key()]
+		if !ok || (field.Type != FieldDropdown && field.Type != FieldCheckboxes) {
+			continue
+		}
+
+		var selected []string
+		for _, value := range raw {
+			if containsString(field.OptionLabels(), value) {
+				selected = append(selected, value)
+			} else {
+				selected = append(selected, SplitValues(value)...)
+			}
+		}
+		result[field.Key()] = selected
+	}
+	return result
+}
+
+// Validate checks a field value against the field's options and validations.
+// Dropdown and checkboxes fields take one value per selected option.
+func (f Field) Validate(values []string) error {
+	switch f.Type {
+	case FieldDropdown, FieldCheckboxes:
+		if f.Type == FieldDropdown && !f.Attributes.Multiple && len(values) > 1 {
+			return fmt.Errorf("field %q accepts a single option", f.Key())
+		}
+		for _, v := range values {
+			if !containsString(f.OptionLabels(), v) {
+				return fmt.Errorf("invalid option %q for field %q (expected one of: %s)", v, f.Key(), strings.Join(f.OptionLabels(), ", "))
+			}
+		}
+		if f.Type == FieldCheckboxes {
+			for _, option := range f.Attributes.Options {
+				if option.Required && !containsString(values, option.Label) {
+					return fmt.Errorf("option %q of field %q must be checked", option.Label, f.Key())
+				}
+			}
+			return nil
+		}
+	}
+
+	if f.Validations.Required && strings.TrimSpace(strings.Join(values, "")) == "" {
+		return fmt.Errorf("field %q is required", f.Key())
+	}
+	return nil
+}
+
+// RenderBody builds the discussion body from the field values in the same
+// way as the web form, keyed by Field.Key
+func (t *Template) RenderBody(values map[string][]string) (string, error) {
+	if err := t.checkKeys(values); err != nil {
+		return "", err
+	}
+
+	var sections []string
+	for _, field := range t.Inputs() {
+		value := values[field.Key()]
+		if err := field.Validate(value); err != nil {
+			return "", err
+		}
+		sections = append(sections, fmt.Sprintf("### %s\n\n%s", field.Attributes.Label, field.render(value)))
+	}
+	return strings.Join(sections, "\n\n"), nil
+}
+
+// checkKeys rejects values that do not belong to any field of the template
+func (t *Template) checkKeys(values map[string][]string) error {
+	var keys []string
+	for _, field := range t.Inputs() {
+		keys = append(keys, field.Key())
+	}
+	for key := range values {
+		if !containsString(keys, key) {
+			return fmt.Errorf("unknown field %q (expected one of: %s)", key, strings.Join(keys, ", "))
+		}
+	}
+	return nil
+}
+
+// render formats a single field value for the discussion body
+func (f Field) render(values []string) string {
+	switch f.Type {
+	case FieldCheckboxes:
+		lines := make([]string, len(f.Attributes.Options))
+		for i, option := range f.Attributes.Options {
+			mark := " "
+			if containsString(values, option.Label) {
+				mark = "X"
+			}
+			lines[i] = fmt.Sprintf("- [%s] %s", mark, option.Label)
+		}
+		return strings.Join(lines, "\n")
+	case FieldDropdown:
+		if len(values) == 0 {
+			return noResponse
+		}
+		return strings.Join(values, ", ")
+	}
+
+	value := strings.TrimSpace(strings.Join(values, "\n"))
+	if value == "" {
+		return noResponse
+	}
+	if f.Type == FieldTextarea && f.Attributes.Render != "" {
+		return fmt.Sprintf("```%s\n%s\n```", f.Attributes.Render, value)
+	}
+	return value
+}
+
+// ParseFieldFlags parses repeated key=value flags into a map. Repeating a key
+// adds another value, for example to check several checkboxes.
+func ParseFieldFlags(flags []string) (map[string][]string, error) {
+	values := make(map[string][]string, len(flags))
+	for _, flag := range flags {
+		key, value, ok := strings.Cut(flag, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid field %q (expected key=value)", flag)
+		}
+		values[key] = append(values[key], value)
+	}
+	return values, nil
+}
+
+// containsString reports whether s is in values
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
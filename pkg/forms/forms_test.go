@@ -0,0 +1,190 @@
+package forms
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testTemplate = `
+title: "[Bug] "
+labels: [bug]
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for reporting!
+  - type: input
+    id: version
+    attributes:
+      label: Version
+    validations:
+      required: true
+  - type: textarea
+    id: logs
+    attributes:
+      label: Logs
+      placeholder: Paste your logs here
+      render: shell
+  - type: dropdown
+    id: os
+    attributes:
+      label: Operating system
+      multiple: true
+      options:
+        - Linux
+        - macOS
+        - Windows, WSL
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Terms
+      options:
+        - label: I searched existing discussions
+          required: true
+        - label: I want to help
+`
+
+func parseTestTemplate(t *testing.T) *Template {
+	t.Helper()
+	tmpl, err := Parse([]byte(testTemplate))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return tmpl
+}
+
+func TestParse(t *testing.T) {
+	tmpl := parseTestTemplate(t)
+	if tmpl.Title != "[Bug] " || !reflect.DeepEqual(tmpl.Labels, []string{"bug"}) {
+		t.Errorf("title = %q, labels = %v", tmpl.Title, tmpl.Labels)
+	}
+	if got := len(tmpl.Inputs()); got != 4 {
+		t.Errorf("got %d inputs, want 4", got)
+	}
+
+	if _, err := Parse([]byte("body:\n  - type: slider\n")); err == nil {
+		t.Error("Parse() accepted an unsupported field type")
+	}
+}
+
+func TestRenderBody(t *testing.T) {
+	tmpl := parseTestTemplate(t)
+
+	body, err := tmpl.RenderBody(map[string][]string{
+		"version": {"1.2.3"},
+		"os":      {"Linux", "Windows, WSL"},
+		"terms":   {"I searched existing discussions"},
+	})
+	if err != nil {
+		t.Fatalf("RenderBody() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		"### Version\n\n1.2.3",
+		"### Logs\n\n_No response_",
+		"### Operating system\n\nLinux, Windows, WSL",
+		"### Terms\n\n- [X] I searched existing discussions\n- [ ] I want to help",
+	}, "\n\n")
+	if body != want {
+		t.Errorf("RenderBody() =\n%s\nwant\n%s", body, want)
+	}
+
+	body, err = tmpl.RenderBody(map[string][]string{
+		"version": {"1.2.3"},
+		"logs":    {"panic: oops\n"},
+		"terms":   {"I searched existing discussions"},
+	})
+	if err != nil {
+		t.Fatalf("RenderBody() error = %v", err)
+	}
+	if !strings.Contains(body, "### Logs\n\n```shell\npanic: oops\n```") {
+		t.Errorf("rendered logs are not in a shell code block:\n%s", body)
+	}
+
+	if _, err := tmpl.RenderBody(map[string][]string{"nope": {"x"}}); err == nil {
+		t.Error("RenderBody() accepted an unknown field")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tmpl := parseTestTemplate(t)
+	fields := make(map[string]Field)
+	for _, field := range tmpl.Inputs() {
+		fields[field.Key()] = field
+	}
+
+	tests := []struct {
+		name    string
+		field   string
+		values  []string
+		wantErr string
+	}{
+		{name: "required input", field: "version", values: []string{"1.0"}},
+		{name: "missing required input", field: "version", values: nil, wantErr: "is required"},
+		{name: "blank required input", field: "version", values: []string{"  "}, wantErr: "is required"},
+		{name: "optional textarea", field: "logs", values: nil},
+		{name: "known option", field: "os", values: []string{"macOS"}},
+		{name: "unknown option", field: "os", values: []string{"BSD"}, wantErr: "invalid option"},
+		{name: "required checkbox", field: "terms", values: []string{"I searched existing discussions", "I want to help"}},
+		{name: "missing required checkbox", field: "terms", values: []string{"I want to help"}, wantErr: "must be checked"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fields[tt.field].Validate(tt.values)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate(%q) error = %v", tt.values, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate(%q) error = %v, want %q", tt.values, err, tt.wantErr)
+			}
+		})
+	}
+
+	single := Field{Type: FieldDropdown, ID: "pick", Attributes: Attributes{Options: []Option{{Label: "a"}, {Label: "b"}}}}
+	if err := single.Validate([]string{"a", "b"}); err == nil {
+		t.Error("single-select dropdown accepted two options")
+	}
+}
+
+func TestSplitFlagValues(t *testing.T) {
+	tmpl := parseTestTemplate(t)
+
+	got := tmpl.SplitFlagValues(map[string][]string{
+		"version": {"1.2, 3"},
+		"os":      {"Linux, macOS", "Windows, WSL"},
+		"terms":   {"I want to help"},
+	})
+	want := map[string][]string{
+		"version": {"1.2, 3"},
+		"os":      {"Linux", "macOS", "Windows, WSL"},
+		"terms":   {"I want to help"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitFlagValues() = %v, want %v", got, want)
+	}
+}
+
+func TestParseFieldFlags(t *testing.T) {
+	got, err := ParseFieldFlags([]string{"version=1.0", "os=Linux", "os=macOS", "logs=a=b"})
+	if err != nil {
+		t.Fatalf("ParseFieldFlags() error = %v", err)
+	}
+	want := map[string][]string{
+		"version": {"1.0"},
+		"os":      {"Linux", "macOS"},
+		"logs":    {"a=b"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseFieldFlags() = %v, want %v", got, want)
+	}
+
+	for _, flag := range []string{"version", "=1.0"} {
+		if _, err := ParseFieldFlags([]string{flag}); err == nil {
+			t.Errorf("ParseFieldFlags(%q) succeeded, want an error", flag)
+		}
+	}
+}
@@ -19,6 +19,9 @@ type Discussion struct {
 	Repository          *Repository        `json:"repository"`
 	URL                 string             `json:"url"`
 	ResourcePath        string             `json:"resourcePath"`
+	Closed              bool               `json:"closed"`
+	ClosedAt            *time.Time         `json:"closedAt"`
+	StateReason         *string            `json:"stateReason"`
 	Locked              bool               `json:"locked"`
 	ActiveLockReason    *string            `json:"activeLockReason"`
 	AnswerChosenAt      *time.Time         `json:"answerChosenAt"`
@@ -55,6 +58,7 @@ type User struct {
 type Category struct {
 	ID           string    `json:"id"`
 	Name         string    `json:"name"`
+	Slug         string    `json:"slug"`
 	Description  string    `json:"description"`
 	Emoji        string    `json:"emoji"`
 	EmojiHTML    string    `json:"emojiHTML"`
@@ -71,11 +75,14 @@ type Repository struct {
 	Owner         *User  `json:"owner"`
 	URL           string `json:"url"`
 	Description   string `json:"description"`
+	// HasDiscussionsEnabled is only populated by queries that request it
+	HasDiscussionsEnabled bool `json:"hasDiscussionsEnabled"`
 }
 
 // Comment represents a discussion comment
 type Comment struct {
 	ID                      string             `json:"id"`
+	DatabaseID              int                `json:"databaseId"`
 	Body                    string             `json:"body"`
 	BodyText                string             `json:"bodyText"`
 	BodyHTML                string             `json:"bodyHTML"`
@@ -84,13 +91,16 @@ type Comment struct {
 	PublishedAt             *time.Time         `json:"publishedAt"`
 	Author                  *User              `json:"author"`
 	AuthorAssociation       string             `json:"authorAssociation"`
+	UpvoteCount             int                `json:"upvoteCount"`
 	IsAnswer                bool               `json:"isAnswer"`
 	IsMinimized             bool               `json:"isMinimized"`
 	MinimizedReason         *string            `json:"minimizedReason"`
 	ReactionGroups          []ReactionGroup    `json:"reactionGroups"`
 	Replies                 *CommentConnection `json:"replies"`
 	ReplyTo                 *Comment           `json:"replyTo"`
+	Discussion              *Discussion        `json:"discussion"`
 	URL                     string             `json:"url"`
+	ViewerCanDelete         bool               `json:"viewerCanDelete"`
 	ViewerCanMarkAsAnswer   bool               `json:"viewerCanMarkAsAnswer"`
 	ViewerCanUnmarkAsAnswer bool               `json:"viewerCanUnmarkAsAnswer"`
 }
@@ -104,6 +114,7 @@ type CommentConnection struct {
 
 // Label represents a GitHub label
 type Label struct {
+	ID    string `json:"id"`
 	Name  string `json:"name"`
 	Color string `json:"color"`
 }
@@ -147,9 +158,13 @@ type ListOptions struct {
 	Search   string
 	Category string
 	Answered *bool
+	State    string
 	Limit    int
 	After    string
 	Labels   []string
+	// Fields are the JSON fields of each discussion to fetch; empty fetches
+	// the fields shown by the list table
+	Fields []string
 }
 
 // ViewOptions represents options for viewing a discussion
@@ -158,4 +173,39 @@ type ViewOptions struct {
 	Repo         string
 	Number       int
 	ShowComments bool
+	// Fields are the JSON fields of the discussion to fetch; empty fetches
+	// every field, with comments only when ShowComments is set
+	Fields []string
+}
+
+// CreateOptions represents options for creating a discussion
+type CreateOptions struct {
+	RepositoryID string
+	CategoryID   string
+	Title        string
+	Body         string
+}
+
+// CommentOptions represents options for adding a discussion comment
+type CommentOptions struct {
+	DiscussionID string
+	Body         string
+	ReplyToID    string
+}
+
+// UpdateOptions represents options for updating a discussion. Empty fields are left unchanged.
+type UpdateOptions struct {
+	DiscussionID string
+	Title        string
+	Body         string
+	CategoryID   string
+}
+
+// RateLimit represents the GraphQL API rate limit status
+type RateLimit struct {
+	Limit     int       `json:"limit"`
+	Cost      int       `json:"cost"`
+	Remaining int       `json:"remaining"`
+	Used      int       `json:"used"`
+	ResetAt   time.Time `json:"resetAt"`
 }
@@ -0,0 +1,70 @@
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/config"
+)
+
+// editorCommand determines the editor to launch, following the same
+// precedence as gh: GH_EDITOR, the gh "editor" setting, VISUAL, then EDITOR.
+// Blank values are treated as unset.
+func editorCommand() string {
+	if editor := strings.TrimSpace(os.Getenv("GH_EDITOR")); editor != "" {
+		return editor
+	}
+	if cfg, err := config.Read(nil); err == nil {
+		if editor, err := cfg.Get([]string{"editor"}); err == nil && strings.TrimSpace(editor) != "" {
+			return strings.TrimSpace(editor)
+		}
+	}
+	if editor := strings.TrimSpace(os.Getenv("VISUAL")); editor != "" {
+		return editor
+	}
+	if editor := strings.TrimSpace(os.Getenv("EDITOR")); editor != "" {
+		return editor
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "nano"
+}
+
+// Editor opens the user's editor with the initial content and returns the edited text
+func Editor(initial string) (string, error) {
+	file, err := os.CreateTemp("", "gh-discussion-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(initial); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	args := strings.Fields(editorCommand())
+	args = append(args, file.Name())
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to run editor %q: %w", args[0], err)
+	}
+
+	content, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read temporary file: %w", err)
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
@@ -0,0 +1,40 @@
+package prompt
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestEditorCommand(t *testing.T) {
+	defaultEditor := "nano"
+	if runtime.GOOS == "windows" {
+		defaultEditor = "notepad"
+	}
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{name: "default", want: defaultEditor},
+		{name: "GH_EDITOR first", env: map[string]string{"GH_EDITOR": "vim", "VISUAL": "code -w", "EDITOR": "emacs"}, want: "vim"},
+		{name: "VISUAL before EDITOR", env: map[string]string{"VISUAL": "code -w", "EDITOR": "emacs"}, want: "code -w"},
+		{name: "EDITOR", env: map[string]string{"EDITOR": " emacs -nw "}, want: "emacs -nw"},
+		{name: "blank GH_EDITOR", env: map[string]string{"GH_EDITOR": "  ", "EDITOR": "emacs"}, want: "emacs"},
+		{name: "all blank", env: map[string]string{"GH_EDITOR": " ", "VISUAL": "\t", "EDITOR": "\n"}, want: defaultEditor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Keep the user's gh config out of the test
+			t.Setenv("GH_CONFIG_DIR", t.TempDir())
+			for _, name := range []string{"GH_EDITOR", "VISUAL", "EDITOR"} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			if got := editorCommand(); got != tt.want {
+				t.Errorf("editorCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package prompt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cli/go-gh/v2/pkg/term"
+)
+
+// ErrCancelled is returned when the user aborts a prompt
+var ErrCancelled = errors.New("prompt cancelled")
+
+var (
+	questionStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("15")).
+			Bold(true)
+
+	cursorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("12")).
+			Bold(true)
+
+	hintStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240"))
+
+	answerStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("14"))
+)
+
+// IsInteractive reports whether both stdin and stdout are attached to a terminal
+func IsInteractive() bool {
+	return term.IsTerminal(os.Stdin) && term.FromEnv().IsTerminalOutput()
+}
+
+// inputModel represents a single-line text input prompt
+type inputModel struct {
+	message   string
+	input     textinput.Model
+	done      bool
+	cancelled bool
+}
+
+func (m inputModel) Init() tea.Cmd { return textinput.Blink }
+
+func (m inputModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.done = true
+			return m, tea.Quit
+		case tea.KeyCtrlC, tea.KeyEsc:
+			m.cancelled = true
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m inputModel) View() string {
+	if m.done {
+		return fmt.Sprintf("%s %s\n", questionStyle.Render(m.message), answerStyle.Render(m.input.Value()))
+	}
+	if m.cancelled {
+		return ""
+	}
+	return fmt.Sprintf("%s %s\n", questionStyle.Render(m.message), m.input.View())
+}
+
+// Input prompts the user for a single line of text
+func Input(message, defaultValue string) (string, error) {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.SetValue(defaultValue)
+	ti.Focus()
+
+	result, err := tea.NewProgram(inputModel{message: message, input: ti}, tea.WithOutput(os.Stderr)).Run()
+	if err != nil {
+		return "", fmt.Errorf("failed to run prompt: %w", err)
+	}
+
+	m := result.(inputModel)
+	if m.cancelled {
+		return "", ErrCancelled
+	}
+	return strings.TrimSpace(m.input.Value()), nil
+}
+
+// selectModel represents a single-choice list prompt
+type selectModel struct {
+	message   string
+	options   []string
+	cursor    int
+	done      bool
+	cancelled bool
+}
+
+func (m selectModel) Init() tea.Cmd { return nil }
+
+func (m selectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "up", "k", "shift+tab":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j", "tab":
+			if m.cursor < len(m.options)-1 {
+				m.cursor++
+			}
+		case "enter":
+			m.done = true
+			return m, tea.Quit
+		case "ctrl+c", "esc", "q":
+			m.cancelled = true
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m selectModel) View() string {
+	if m.done {
+		return fmt.Sprintf("%s %s\n", questionStyle.Render(m.message), answerStyle.Render(m.options[m.cursor]))
+	}
+	if m.cancelled {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", questionStyle.Render(m.message), hintStyle.Render("[Use arrows to move, enter to select]"))
+	for i, option := range m.options {
+		if i == m.cursor {
+			fmt.Fprintf(&b, "%s %s\n", cursorStyle.Render(">"), cursorStyle.Render(option))
+		} else {
+			fmt.Fprintf(&b, "  %s\n", option)
+		}
+	}
+	return b.String()
+}
+
+// Select prompts the user to choose one of the options and returns its index
+func Select(message string, options []string) (int, error) {
+	if len(options) == 0 {
+		return 0, fmt.Errorf("no options to select from")
+	}
+
+	result, err := tea.NewProgram(selectModel{message: message, options: options}, tea.WithOutput(os.Stderr)).Run()
+	if err != nil {
+		return 0, fmt.Errorf("failed to run prompt: %w", err)
+	}
+
+	m := result.(selectModel)
+	if m.cancelled {
+		return 0, ErrCancelled
+	}
+	return m.cursor, nil
+}
+
+// multiSelectModel represents a multiple-choice list prompt
+type multiSelectModel struct {
+	message   string
+	options   []string
+	selected  map[int]bool
+	cursor    int
+	done      bool
+	cancelled bool
+}
+
+func (m multiSelectModel) Init() tea.Cmd { return nil }
+
+func (m multiSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "up", "k", "shift+tab":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j", "tab":
+			if m.cursor < len(m.options)-1 {
+				m.cursor++
+			}
+		case " ", "x":
+			m.selected[m.cursor] = !m.selected[m.cursor]
+		case "enter":
+			m.done = true
+			return m, tea.Quit
+		case "ctrl+c", "esc", "q":
+			m.cancelled = true
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m multiSelectModel) View() string {
+	if m.done {
+		var chosen []string
+		for i, option := range m.options {
+			if m.selected[i] {
+				chosen = append(chosen, option)
+			}
+		}
+		return fmt.Sprintf("%s %s\n", questionStyle.Render(m.message), answerStyle.Render(strings.Join(chosen, ", ")))
+	}
+	if m.cancelled {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", questionStyle.Render(m.message), hintStyle.Render("[Use arrows to move, space to select, enter to confirm]"))
+	for i, option := range m.options {
+		box := "[ ]"
+		if m.selected[i] {
+			box = "[x]"
+		}
+		if i == m.cursor {
+			fmt.Fprintf(&b, "%s %s\n", cursorStyle.Render("> "+box), cursorStyle.Render(option))
+		} else {
+			fmt.Fprintf(&b, "  %s %s\n", box, option)
+		}
+	}
+	return b.String()
+}
+
+// MultiSelect prompts the user to choose any number of the options and returns
+// their indices in order
+func MultiSelect(message string, options []string, defaults []int) ([]int, error) {
+	if len(options) == 0 {
+		return nil, fmt.Errorf("no options to select from")
+	}
+
+	selected := make(map[int]bool, len(defaults))
+	for _, i := range defaults {
+		selected[i] = true
+	}
+
+	result, err := tea.NewProgram(multiSelectModel{message: message, options: options, selected: selected}, tea.WithOutput(os.Stderr)).Run()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run prompt: %w", err)
+	}
+
+	m := result.(multiSelectModel)
+	if m.cancelled {
+		return nil, ErrCancelled
+	}
+
+	var indices []int
+	for i := range m.options {
+		if m.selected[i] {
+			indices = append(indices, i)
+		}
+	}
+	return indices, nil
+}